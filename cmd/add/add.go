@@ -0,0 +1,108 @@
+package add
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/okalexiiis/dwrk/internal/config"
+	"github.com/okalexiiis/dwrk/internal/project"
+	"github.com/okalexiiis/dwrk/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// flags
+var (
+	alias       string
+	projectType string
+	tags        []string
+)
+
+// AddCmd defines the `dwrk add` command.
+//
+// It records an existing directory in the project registry so its
+// metadata survives between runs. The directory may live anywhere on disk;
+// once registered, other commands resolve it by its name or alias.
+var AddCmd = &cobra.Command{
+	Use:   "add <path>",
+	Short: "Register an existing directory as a project",
+	Long: `Records an existing directory in the project registry (~/.config/dwrk/projects.yaml).
+
+The directory does not need to live inside projects_dir. By default the
+project is named after the directory; use --name to choose an alias.
+Tags given with --tag replace the project's current tags and can be used to
+select groups of projects (e.g. dwrk exec --tag backend); --tag "" clears
+them. Without --tag the current tags are kept.
+
+Examples:
+  dwrk add ~/work/billing
+  dwrk add /srv/checkouts/api --name prod-api
+  dwrk add ~/work/billing --tag backend,payments
+  dwrk add ~/work/billing --tag ""`,
+	Args: cobra.ExactArgs(1),
+	Run:  runAdd,
+}
+
+func init() {
+	AddCmd.Flags().StringVarP(&alias, "name", "n", "", "Name to register the project under (default: directory name)")
+	AddCmd.Flags().StringVar(&projectType, "type", "", "Project type (e.g. standalone, monorepo)")
+	AddCmd.Flags().StringSliceVar(&tags, "tag", nil, "Tags of the project (comma-separated or repeated)")
+}
+
+func runAdd(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	projectPath, err := filepath.Abs(utils.ExpandPath(args[0]))
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	info, err := os.Stat(projectPath)
+	if err != nil || !info.IsDir() {
+		fmt.Fprintf(os.Stderr, "Error: '%s' is not an existing directory\n", projectPath)
+		os.Exit(1)
+	}
+
+	manager := project.NewManager(cfg)
+
+	// Without --name, a registered directory keeps its name instead of being
+	// renamed after the directory.
+	name := alias
+	if name == "" {
+		name = filepath.Base(projectPath)
+		if reg, err := manager.Registry(); err == nil {
+			if regName, _, ok := reg.FindByPath(projectPath); ok {
+				name = regName
+			}
+		}
+	}
+
+	// An explicit --tag "" clears the tags; nil keeps the current ones.
+	if cmd.Flags().Changed("tag") && tags == nil {
+		tags = []string{}
+	}
+
+	proj, err := manager.Register(name, projectPath, project.RegisterOptions{
+		Type: projectType,
+		Tags: tags,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Project registered: %s\n", proj.Name)
+	fmt.Printf("Location: %s\n", proj.Path)
+	if len(proj.Tags) > 0 {
+		fmt.Printf("Tags: %s\n", strings.Join(proj.Tags, ", "))
+	}
+
+	fmt.Println("\nTo open the project:")
+	fmt.Printf("  dwrk open %s\n", proj.Name)
+}
@@ -0,0 +1,298 @@
+package clone
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/okalexiiis/dwrk/internal/config"
+	"github.com/okalexiiis/dwrk/internal/git"
+	"github.com/okalexiiis/dwrk/internal/github"
+	"github.com/okalexiiis/dwrk/internal/project"
+	"github.com/okalexiiis/dwrk/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// Estados posibles de cada repositorio en un clon masivo
+const (
+	statusCloned  = "clonado"
+	statusSkipped = "omitido"
+	statusFailed  = "error"
+)
+
+// bulkResult es el resultado de clonar un repositorio
+type bulkResult struct {
+	repo   github.Repository
+	path   string
+	status string
+	detail string
+}
+
+// runBulkClone clona todos los repositorios de un usuario u organización
+func runBulkClone(cmd *cobra.Command, cfg *config.Config) {
+	owner := org
+	if owner == "" {
+		owner = username
+	}
+	if owner == "" {
+		fmt.Fprintln(os.Stderr, "❌ Error: indica --org o --user (o configura github_username)")
+		os.Exit(1)
+	}
+	if jobs < 1 {
+		fmt.Fprintln(os.Stderr, "❌ Error: --jobs debe ser al menos 1")
+		os.Exit(1)
+	}
+
+	client := github.NewClientFromConfig(cfg)
+
+	fmt.Printf("🔍 Listando repositorios de %s...\n", owner)
+
+	var repos []github.Repository
+	var err error
+	if org != "" {
+		repos, err = client.ListOrgRepos(cmd.Context(), owner)
+	} else {
+		repos, err = client.ListUserRepos(cmd.Context(), owner)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Error al listar repositorios: %v\n", err)
+		os.Exit(1)
+	}
+
+	repos = filterRepos(repos)
+	if len(repos) == 0 {
+		fmt.Println("📭 Ningún repositorio coincide con los filtros")
+		return
+	}
+
+	manager := project.NewManager(cfg)
+
+	targetPath := utils.ExpandPath(destDir)
+	if destDir == "" {
+		if targetPath, err = manager.ProjectsDir(); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	if err := os.MkdirAll(targetPath, 0755); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Error: no se pudo crear el directorio destino: %v\n", err)
+		os.Exit(1)
+	}
+
+	// Los repositorios que ya existen localmente no se vuelven a clonar: se
+	// reconocen por el remoto origin de los proyectos o por el directorio
+	// destino, no por el nombre, que puede pertenecer a otro repositorio
+	checkouts := gitCheckouts(manager)
+	results := make([]bulkResult, len(repos))
+	var pending []int
+
+	for i, repo := range repos {
+		results[i] = bulkResult{repo: repo, path: bulkDestination(targetPath, repo)}
+
+		if proj := findCheckout(checkouts, repo); proj != nil {
+			results[i].status = statusSkipped
+			results[i].detail = "ya existe en " + proj.Path
+			continue
+		}
+		if _, err := os.Stat(results[i].path); err == nil {
+			results[i].status = statusSkipped
+			results[i].detail = "el directorio ya existe"
+			continue
+		}
+
+		pending = append(pending, i)
+	}
+
+	if len(pending) > 0 {
+		fmt.Printf("📥 Clonando %d de %d repositorios en %s (%d en paralelo)...\n",
+			len(pending), len(repos), targetPath, min(jobs, len(pending)))
+	}
+
+	cloneAll(cmd.Context(), results, pending)
+
+	// El registro no admite escrituras concurrentes: se actualiza al final
+	for i := range results {
+		if results[i].status != statusCloned {
+			continue
+		}
+		if _, err := manager.Register(results[i].repo.Name, results[i].path, project.RegisterOptions{}); err != nil {
+			results[i].detail = fmt.Sprintf("no se pudo registrar: %v", err)
+		}
+	}
+
+	failed := printBulkResults(results)
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// cloneAll clona los repositorios indicados por pending con un número
+// limitado de workers, guardando el resultado en results
+func cloneAll(ctx context.Context, results []bulkResult, pending []int) {
+	utils.Parallel(len(pending), jobs, func(i int) {
+		cloneOne(ctx, &results[pending[i]])
+	})
+}
+
+// cloneOne clona un único repositorio; la salida de Git se captura para que
+// los clones en paralelo no se mezclen en la terminal
+func cloneOne(ctx context.Context, result *bulkResult) {
+	var output bytes.Buffer
+	_, err := git.NewCloner().Clone(ctx, repoURLOf(result.repo), result.path, git.CloneOptions{
+		Depth:             depth,
+		RecurseSubmodules: recurseSubmodules,
+		Quiet:             true,
+		Stdout:            &output,
+		Stderr:            &output,
+	})
+	if err != nil {
+		result.status = statusFailed
+		result.detail = gitErrorDetail(err, output.String())
+		return
+	}
+
+	result.status = statusCloned
+}
+
+// repoURLOf devuelve la URL SSH o HTTPS del repositorio según la preferencia
+func repoURLOf(repo github.Repository) string {
+	if useHTTPS || repo.SSHURL == "" {
+		return repo.CloneURL
+	}
+	return repo.SSHURL
+}
+
+// bulkDestination devuelve la ruta del clon de un repositorio según el layout
+func bulkDestination(targetPath string, repo github.Repository) string {
+	if ref, err := utils.ParseRepoRef(repoURLOf(repo)); err == nil {
+		return filepath.Join(targetPath, layoutSubdir(ref), repo.Name)
+	}
+	return filepath.Join(targetPath, repo.Name)
+}
+
+// gitErrorDetail devuelve el primer mensaje "fatal:" de la salida de Git,
+// que suele ser más descriptivo que el código de salida
+func gitErrorDetail(err error, output string) string {
+	for _, line := range strings.Split(output, "\n") {
+		if msg, ok := strings.CutPrefix(strings.TrimSpace(line), "fatal: "); ok {
+			return msg
+		}
+	}
+	return err.Error()
+}
+
+// checkout es un proyecto Git existente junto con la URL de su origin
+type checkout struct {
+	proj   project.Project
+	origin string
+}
+
+// gitCheckouts devuelve los proyectos Git existentes que tienen un origin
+func gitCheckouts(manager *project.Manager) []checkout {
+	projects, err := manager.List(project.ListOptions{ShowHidden: true})
+	if err != nil {
+		return nil
+	}
+
+	found := make([]checkout, len(projects))
+	utils.Parallel(len(projects), jobs, func(i int) {
+		if projects[i].IsGit && !projects[i].Missing {
+			found[i] = checkout{proj: projects[i], origin: git.RemoteURL(projects[i].Path, "origin")}
+		}
+	})
+
+	var checkouts []checkout
+	for _, c := range found {
+		if c.origin != "" {
+			checkouts = append(checkouts, c)
+		}
+	}
+	return checkouts
+}
+
+// findCheckout devuelve el proyecto cuyo origin es el repositorio, si existe
+func findCheckout(checkouts []checkout, repo github.Repository) *project.Project {
+	for i, c := range checkouts {
+		for _, repoURL := range []string{repo.SSHURL, repo.CloneURL} {
+			if repoURL != "" && sameRepo(c.origin, repoURL) {
+				return &checkouts[i].proj
+			}
+		}
+	}
+	return nil
+}
+
+// filterRepos aplica los filtros de topic, lenguaje, archivados y forks
+func filterRepos(repos []github.Repository) []github.Repository {
+	var filtered []github.Repository
+
+	for _, repo := range repos {
+		if repo.Archived && !includeArchived {
+			continue
+		}
+		if repo.Fork && !includeForks {
+			continue
+		}
+		if language != "" && !strings.EqualFold(repo.Language, language) {
+			continue
+		}
+		if !hasTopics(repo, topics) {
+			continue
+		}
+		filtered = append(filtered, repo)
+	}
+
+	return filtered
+}
+
+// hasTopics indica si el repositorio tiene todos los topics indicados
+func hasTopics(repo github.Repository, wanted []string) bool {
+	for _, topic := range wanted {
+		found := false
+		for _, t := range repo.Topics {
+			if strings.EqualFold(t, topic) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// printBulkResults imprime la tabla de resultados y devuelve cuántos fallaron
+func printBulkResults(results []bulkResult) int {
+	var cloned, skipped, failed int
+
+	fmt.Println()
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "REPOSITORIO\tESTADO\tDETALLE")
+
+	for _, r := range results {
+		switch r.status {
+		case statusCloned:
+			cloned++
+		case statusSkipped:
+			skipped++
+		case statusFailed:
+			failed++
+		}
+
+		detail := r.detail
+		if detail == "" {
+			detail = r.path
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.repo.Name, r.status, detail)
+	}
+	w.Flush()
+
+	fmt.Printf("\n📊 %d clonados, %d omitidos, %d con error\n", cloned, skipped, failed)
+	return failed
+}
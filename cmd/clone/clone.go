@@ -15,17 +15,54 @@ import (
 
 // flags
 var (
-	username string
-	url      string
-	useHTTPS bool
-	destDir  string
+	username          string
+	url               string
+	useHTTPS          bool
+	destDir           string
+	depth             int
+	branch            string
+	recurseSubmodules bool
+	sparse            []string
+	dirName           string
+	hostName          string
+	layout            string
+
+	// clon masivo
+	all             bool
+	org             string
+	topics          []string
+	language        string
+	includeArchived bool
+	includeForks    bool
+	jobs            int
 )
 
 var CloneCmd = &cobra.Command{
-	Use:   "clone <repo>",
+	Use:   "clone [repo]",
 	Short: "Clona un repositorio de GitHub",
-	Args:  cobra.MaximumNArgs(1),
-	Run:   runClone,
+	Long: `Clona un repositorio de GitHub y lo registra como proyecto.
+
+El repositorio puede indicarse como <repo>, <owner>/<repo> o
+<host>:<owner>/<repo>, donde <host> es uno de los hosts de la configuración
+(github, gitlab, bitbucket, codeberg o los definidos en "hosts"). Sin owner se
+usa el namespace del host (en GitHub, github_username).
+
+Con --all se clonan todos los repositorios de una organización (--org) o de
+un usuario (--user) que todavía no existan localmente. El token configurado
+(github_token o GITHUB_TOKEN) se usa para acceder a repositorios privados; con
+--user solo se incluyen los del propietario del token.
+
+Ejemplos:
+  dwrk clone portfolio-site
+  dwrk clone api --branch develop --depth 1
+  dwrk clone gitlab:team/backend/api
+  dwrk clone --host work billing
+  dwrk clone monorepo --sparse services/auth,libs/common
+  dwrk clone --url git@github.com:org/repo.git --name repo-fork
+  dwrk clone --all --org our-org --topic backend --jobs 8
+  dwrk clone --all --user octocat --language go --forks`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runClone,
 }
 
 func init() {
@@ -33,6 +70,21 @@ func init() {
 	CloneCmd.Flags().StringVar(&url, "url", "", "URL completa del repositorio")
 	CloneCmd.Flags().BoolVar(&useHTTPS, "https", false, "Usar HTTPS en lugar de SSH")
 	CloneCmd.Flags().StringVar(&destDir, "dir", "", "Directorio destino")
+	CloneCmd.Flags().IntVar(&depth, "depth", 0, "Clon superficial con el número de commits indicado")
+	CloneCmd.Flags().StringVarP(&branch, "branch", "b", "", "Rama o tag a clonar")
+	CloneCmd.Flags().BoolVar(&recurseSubmodules, "recurse-submodules", false, "Clonar también los submódulos")
+	CloneCmd.Flags().StringSliceVar(&sparse, "sparse", nil, "Rutas a descargar con sparse checkout (separadas por coma)")
+	CloneCmd.Flags().StringVar(&hostName, "host", "", "Host configurado desde el que clonar (por defecto: default_host)")
+	CloneCmd.Flags().StringVar(&layout, "layout", "", "Organización de los clones: flat o host (<dir>/<host>/<owner>/<repo>)")
+	CloneCmd.Flags().StringVar(&dirName, "name", "", "Nombre del directorio del clon (por defecto: nombre del repositorio)")
+
+	CloneCmd.Flags().BoolVar(&all, "all", false, "Clonar todos los repositorios de la organización o usuario")
+	CloneCmd.Flags().StringVar(&org, "org", "", "Organización de GitHub (con --all)")
+	CloneCmd.Flags().StringSliceVar(&topics, "topic", nil, "Solo repositorios con estos topics (con --all)")
+	CloneCmd.Flags().StringVar(&language, "language", "", "Solo repositorios con este lenguaje principal (con --all)")
+	CloneCmd.Flags().BoolVar(&includeArchived, "archived", false, "Incluir repositorios archivados (con --all)")
+	CloneCmd.Flags().BoolVar(&includeForks, "forks", false, "Incluir forks (con --all)")
+	CloneCmd.Flags().IntVarP(&jobs, "jobs", "j", 4, "Clones en paralelo (con --all)")
 }
 
 func runClone(cmd *cobra.Command, args []string) {
@@ -53,65 +105,107 @@ func runClone(cmd *cobra.Command, args []string) {
 		useHTTPS = !cfg.UseSSH
 	}
 
+	if layout == "" {
+		layout = cfg.CloneLayout
+	}
+	if layout != "" && layout != config.LayoutFlat && layout != config.LayoutHost {
+		fmt.Fprintf(os.Stderr, "❌ Error: --layout debe ser '%s' o '%s'\n", config.LayoutFlat, config.LayoutHost)
+		os.Exit(1)
+	}
+
+	if all {
+		if len(args) > 0 || url != "" || dirName != "" {
+			fmt.Fprintln(os.Stderr, "❌ Error: --all no admite un repositorio, --url ni --name")
+			os.Exit(1)
+		}
+		runBulkClone(cmd, cfg)
+		return
+	}
+	if org != "" {
+		fmt.Fprintln(os.Stderr, "❌ Error: --org solo se puede usar con --all")
+		os.Exit(1)
+	}
+
 	var repoURL string
-	var repoName string
+	var ref *utils.RepoRef
 
 	if url != "" {
 		repoURL = url
-		repoName = utils.ExtractRepoNameFromURL(url)
-		fmt.Printf("🔗 Clonando desde URL: %s\n", repoURL)
+		if ref, err = utils.ParseRepoRef(repoURL); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
+			os.Exit(1)
+		}
 	} else {
 		if len(args) == 0 {
 			fmt.Fprintln(os.Stderr, "❌ Error: debes proporcionar un nombre de repositorio o usar --url")
 			os.Exit(1)
 		}
 
-		repoName = args[0]
-
-		// Usar PROJECTS_DIR de config
-		projectsPath := cfg.ProjectsDir
-		manager := project.NewManager(projectsPath)
-
-		if destDir == "" {
-			if manager.Exists(repoName) {
-				destDir = filepath.Join(cfg.ProjectsDir, repoName)
-				fmt.Printf("📁 Encontrado proyecto local '%s'\n", repoName)
-				fmt.Printf("📥 Clonando dentro de: %s\n", destDir)
-			} else {
-				fmt.Printf("⚠️  No existe un proyecto local llamado '%s'\n", repoName)
-				fmt.Print("¿Deseas clonar en PROJECTS_DIR y crear el directorio? [Y/n]: ")
+		host, repoPath, err := resolveRepo(cmd, cfg, args[0])
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
+			os.Exit(1)
+		}
+		repoURL = host.RepoURL(repoPath, useHTTPS)
+		if ref, err = utils.ParseRepoRef(repoURL); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
+			os.Exit(1)
+		}
+	}
 
-				var response string
-				fmt.Scanln(&response)
+	name := dirName
+	if name == "" {
+		name = ref.Name
+	}
 
-				response = strings.ToLower(strings.TrimSpace(response))
-				if response == "n" || response == "no" {
-					fmt.Println("❌ Operación cancelada")
-					os.Exit(0)
-				}
+	manager := project.NewManager(cfg)
 
-				destDir = cfg.ProjectsDir
+	// Determinar el directorio exacto del clon
+	var dest string
+	if destDir != "" {
+		dest = filepath.Join(utils.ExpandPath(destDir), layoutSubdir(ref), name)
+	} else if proj, err := manager.GetExact(name); err == nil && !proj.Missing {
+		// El proyecto puede estar registrado con un alias fuera de PROJECTS_DIR.
+		// Se clona dentro de él, así que el nombre tiene que ser exacto
+		dest = proj.Path
+		fmt.Printf("📁 Encontrado proyecto local '%s' en %s\n", name, dest)
+	} else {
+		if url == "" {
+			fmt.Printf("⚠️  No existe un proyecto local llamado '%s'\n", name)
+			if similar := similarProjects(manager, name); len(similar) > 0 {
+				fmt.Printf("   Proyectos parecidos: %s (usa --name para clonar en uno de ellos)\n", strings.Join(similar, ", "))
+			}
+			if !confirm("¿Deseas clonar en PROJECTS_DIR y crear el directorio?", true) {
+				fmt.Println("❌ Operación cancelada")
+				os.Exit(0)
 			}
 		}
-
-		repoURL = utils.BuildRepoURL(username, repoName, useHTTPS)
-
-		protocol := "SSH"
-		if useHTTPS {
-			protocol = "HTTPS"
+		projectsDir, err := manager.ProjectsDir()
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
+			os.Exit(1)
 		}
-		fmt.Printf("🔗 Clonando %s/%s (%s)...\n", username, repoName, protocol)
+		dest = filepath.Join(projectsDir, layoutSubdir(ref), name)
 	}
 
-	targetPath := utils.ExpandPath(destDir)
-
-	if err := os.MkdirAll(targetPath, 0755); err != nil {
-		fmt.Fprintf(os.Stderr, "❌ Error: no se pudo crear el directorio destino: %v\n", err)
+	// Nunca clonar dentro de un directorio con contenido: se rechaza o se adopta
+	if empty, err := utils.IsEmptyDir(dest); err != nil {
+		fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
 		os.Exit(1)
+	} else if !empty {
+		adoptExisting(manager, name, dest, repoURL)
+		return
 	}
 
+	fmt.Printf("🔗 Clonando %s en %s...\n", repoURL, dest)
+
 	cloner := git.NewCloner()
-	clonedPath, err := cloner.Clone(repoURL, targetPath)
+	clonedPath, err := cloner.Clone(cmd.Context(), repoURL, dest, git.CloneOptions{
+		Branch:            branch,
+		Depth:             depth,
+		RecurseSubmodules: recurseSubmodules,
+		Sparse:            sparse,
+	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "❌ Error al clonar repositorio: %v\n", err)
 		os.Exit(1)
@@ -119,6 +213,155 @@ func runClone(cmd *cobra.Command, args []string) {
 
 	fmt.Printf("✅ Repositorio clonado exitosamente\n")
 	fmt.Printf("📁 Ubicación: %s\n", clonedPath)
+
+	registerClone(manager, name, clonedPath)
+}
+
+// adoptExisting trata un destino que ya existe y no está vacío. Si es un clon
+// del mismo repositorio, o un repositorio Git sin origin, ofrece registrarlo
+// como proyecto (añadiendo origin si falta); en cualquier otro caso se rechaza
+func adoptExisting(manager *project.Manager, name, dest, repoURL string) {
+	if !utils.IsGitRepo(dest) {
+		fmt.Fprintf(os.Stderr, "❌ Error: %s ya existe y no está vacío\n", dest)
+		fmt.Fprintln(os.Stderr, "   Usa --name o --dir para clonar en otro directorio")
+		os.Exit(1)
+	}
+
+	origin := git.RemoteURL(dest, "origin")
+	switch {
+	case origin == "":
+		fmt.Printf("📁 %s ya es un repositorio Git sin origin\n", dest)
+		if !confirm(fmt.Sprintf("¿Adoptarlo añadiendo %s como origin?", repoURL), false) {
+			fmt.Println("❌ Operación cancelada")
+			os.Exit(1)
+		}
+		if err := git.AddRemote(dest, "origin", repoURL); err != nil {
+			fmt.Fprintf(os.Stderr, "❌ Error: %v\n", err)
+			os.Exit(1)
+		}
+
+	case sameRepo(origin, repoURL):
+		fmt.Printf("📁 El repositorio ya está clonado en %s\n", dest)
+		if !confirm("¿Registrarlo como proyecto?", true) {
+			os.Exit(0)
+		}
+
+	default:
+		fmt.Fprintf(os.Stderr, "❌ Error: %s ya es un clon de otro repositorio (%s)\n", dest, origin)
+		fmt.Fprintln(os.Stderr, "   Usa --name o --dir para clonar en otro directorio")
+		os.Exit(1)
+	}
+
+	registerClone(manager, name, dest)
+}
+
+// registerClone registra el proyecto clonado; el clon ya existe, así que un
+// fallo aquí no es fatal
+func registerClone(manager *project.Manager, name, path string) {
+	if _, err := manager.Register(name, path, project.RegisterOptions{}); err != nil {
+		fmt.Fprintf(os.Stderr, "⚠️  No se pudo registrar el proyecto: %v\n", err)
+	}
 	fmt.Printf("\n💡 Para abrir el proyecto:\n")
-	fmt.Printf("   dwrk open %s\n", repoName)
+	fmt.Printf("   dwrk open %s\n", name)
+}
+
+// similarProjects devuelve los nombres de hasta tres proyectos que coinciden
+// de forma aproximada con name, el mejor primero
+func similarProjects(manager *project.Manager, name string) []string {
+	matches, err := manager.List(project.ListOptions{Search: name})
+	if err != nil {
+		return nil
+	}
+
+	var names []string
+	for _, proj := range matches[:min(len(matches), 3)] {
+		names = append(names, proj.Name)
+	}
+	return names
+}
+
+// sameRepo indica si dos URLs apuntan al mismo repositorio, sin importar el
+// protocolo (SSH o HTTPS)
+func sameRepo(a, b string) bool {
+	refA, errA := utils.ParseRepoRef(a)
+	refB, errB := utils.ParseRepoRef(b)
+	if errA != nil || errB != nil {
+		return a == b
+	}
+	return strings.EqualFold(refA.Host, refB.Host) && strings.EqualFold(refA.Path(), refB.Path())
+}
+
+// confirm pregunta sí/no; una respuesta vacía devuelve def
+func confirm(question string, def bool) bool {
+	hint := "[y/N]"
+	if def {
+		hint = "[Y/n]"
+	}
+	fmt.Printf("%s %s: ", question, hint)
+
+	var response string
+	fmt.Scanln(&response)
+
+	switch strings.ToLower(strings.TrimSpace(response)) {
+	case "y", "yes", "s", "si", "sí":
+		return true
+	case "n", "no":
+		return false
+	default:
+		return def
+	}
+}
+
+// resolveRepo determina el host y la ruta del repositorio (owner/.../repo) a
+// partir del argumento, el flag --host y el namespace por defecto del host
+func resolveRepo(cmd *cobra.Command, cfg *config.Config, arg string) (*config.Host, string, error) {
+	name := hostName
+	repoPath := arg
+
+	if prefix, rest, ok := strings.Cut(arg, ":"); ok {
+		if name != "" && name != prefix {
+			return nil, "", fmt.Errorf("el host '%s' no coincide con --host %s", prefix, name)
+		}
+		name, repoPath = prefix, rest
+	}
+
+	var host *config.Host
+	var err error
+	if name != "" {
+		host, err = cfg.Host(name)
+	} else {
+		host, err = cfg.DefaultHost()
+	}
+	if err != nil {
+		return nil, "", err
+	}
+
+	repoPath = strings.TrimSuffix(strings.Trim(repoPath, "/"), ".git")
+	if repoPath == "" {
+		return nil, "", fmt.Errorf("falta el nombre del repositorio")
+	}
+
+	if !strings.Contains(repoPath, "/") {
+		namespace := host.Namespace
+		if cmd.Flags().Changed("user") {
+			namespace = username
+		}
+		if namespace == "" {
+			return nil, "", fmt.Errorf("el host '%s' no tiene namespace por defecto; usa %s:<owner>/%s", host.Name, host.Name, repoPath)
+		}
+		repoPath = namespace + "/" + repoPath
+	}
+
+	return host, repoPath, nil
+}
+
+// layoutSubdir devuelve el subdirectorio <host>/<owner> de un repositorio en
+// el layout "host", o una cadena vacía en el layout plano. Los repositorios
+// locales (rutas y file://) no tienen host ni owner: su "owner" sería la ruta
+// completa, así que siempre usan el layout plano
+func layoutSubdir(ref *utils.RepoRef) string {
+	if layout != config.LayoutHost || ref.Protocol == utils.ProtocolFile || ref.Host == "" {
+		return ""
+	}
+	return filepath.Join(ref.Host, filepath.FromSlash(ref.Owner))
 }
@@ -3,6 +3,7 @@ package config
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/okalexiiis/dwrk/internal/config"
 	"github.com/spf13/cobra"
@@ -27,16 +28,39 @@ var setCmd = &cobra.Command{
 	Long: `Set a configuration key.
 
 Available keys:
-  projects_dir      Base directory for projects
+  projects_dir      Base directory for projects (new projects are created here)
+  projects_dirs     Additional directories scanned for projects (comma-separated)
+  scan_depth        How many directory levels are scanned for projects
   editor            Default editor (auto, code, nvim, vim, nano, terminal)
   github_username   GitHub username
+  github_api_url    GitHub REST API base URL (default: https://api.github.com)
+  github_token      GitHub API token, stored in plain text (prefer $GITHUB_TOKEN)
   use_ssh           Use SSH for Git operations (true/false)
+  clone_layout      Where clones go: flat (<dir>/<repo>) or host (<dir>/<host>/<owner>/<repo>)
+  default_host      Host used by 'dwrk clone' when none is given (default: github)
+  init_branch       Initial branch of new repositories (default: Git's init.defaultBranch)
+  init_gitignore    .gitignore templates added to new repositories (comma-separated)
+  init_license      SPDX identifier of the license added to new repositories
+  license_holder    Copyright holder in licenses (default: the commit author name)
+  skip_initial_commit  Leave new repositories without a commit (true/false)
+  git_user_name     Commit author of new repositories (default: Git's user.name)
+  git_user_email    Commit email of new repositories (default: Git's user.email)
+
+Git hosts (GitLab, Gitea/Forgejo, Bitbucket, self-hosted instances) are
+declared in the "hosts" section of the configuration file. A host can set
+user_name and user_email to commit with a different identity in projects
+created with 'dwrk new --host <name>'.
 
 Examples:
   dwrk config set projects_dir ~/Dev
+  dwrk config set projects_dirs ~/work,~/oss
+  dwrk config set scan_depth 2
   dwrk config set editor code
   dwrk config set github_username myuser
-  dwrk config set use_ssh false`,
+  dwrk config set use_ssh false
+  dwrk config set default_host work
+  dwrk config set init_gitignore go,macos
+  dwrk config set init_license MIT`,
 	Args: cobra.ExactArgs(2),
 	Run:  runSet,
 }
@@ -98,6 +122,13 @@ func runSet(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	if isTokenKey(key) {
+		fmt.Printf("Configuration updated: %s = %s\n", key, maskToken(value))
+		fmt.Printf("\nTip: the token is stored in plain text in %s;\n", config.GetConfigPath())
+		fmt.Println("     prefer exporting GITHUB_TOKEN instead")
+		return
+	}
+
 	fmt.Printf("Configuration updated: %s = %s\n", key, value)
 }
 
@@ -116,6 +147,9 @@ func runGet(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
+	if isTokenKey(key) {
+		value = maskToken(value)
+	}
 	fmt.Println(value)
 }
 
@@ -129,14 +163,47 @@ func runList(cmd *cobra.Command, args []string) {
 	fmt.Println("Current configuration:")
 	fmt.Println()
 	fmt.Printf("  projects_dir:     %s\n", cfg.ProjectsDir)
+	fmt.Printf("  projects_dirs:    %s\n", strings.Join(cfg.ProjectsDirs, ", "))
+	fmt.Printf("  scan_depth:       %d\n", cfg.ScanDepth)
 	fmt.Printf("  templates_dir:    %s\n", cfg.TemplatesDir)
 	fmt.Printf("  default_editor:   %s\n", cfg.DefaultEditor)
 	fmt.Printf("  github_username:  %s\n", cfg.GitHubUsername)
+	fmt.Printf("  github_api_url:   %s\n", cfg.GitHubAPIURL)
+	fmt.Printf("  github_token:     %s\n", maskToken(cfg.GitHubToken))
 	fmt.Printf("  use_ssh:          %v\n", cfg.UseSSH)
+	fmt.Printf("  clone_layout:     %s\n", cfg.CloneLayout)
+	fmt.Printf("  default_host:     %s\n", cfg.DefaultHostName)
+	fmt.Printf("  init_branch:      %s\n", cfg.InitBranch)
+	fmt.Printf("  init_gitignore:   %s\n", strings.Join(cfg.InitGitignore, ", "))
+	fmt.Printf("  init_license:     %s\n", cfg.InitLicense)
+	fmt.Printf("  license_holder:   %s\n", cfg.LicenseHolder)
+	fmt.Printf("  skip_initial_commit: %v\n", cfg.SkipInitialCommit)
+	fmt.Printf("  git_user_name:    %s\n", cfg.GitUserName)
+	fmt.Printf("  git_user_email:   %s\n", cfg.GitUserEmail)
+	if len(cfg.Hosts) > 0 {
+		fmt.Println("  hosts:")
+		for _, h := range cfg.Hosts {
+			fmt.Printf("    %-14s  %s (%s)\n", h.Name+":", h.BaseURL, h.Kind)
+		}
+	}
 	fmt.Println()
 	fmt.Printf("Configuration file: %s\n", config.GetConfigPath())
 }
 
+// isTokenKey reports whether key names the GitHub token, which is never
+// printed in full.
+func isTokenKey(key string) bool {
+	return key == "github_token" || key == "token"
+}
+
+// maskToken hides all but the last characters of a secret.
+func maskToken(token string) string {
+	if len(token) <= 4 {
+		return strings.Repeat("*", len(token))
+	}
+	return strings.Repeat("*", 8) + token[len(token)-4:]
+}
+
 func runPath(cmd *cobra.Command, args []string) {
 	fmt.Println(config.GetConfigPath())
 }
@@ -0,0 +1,235 @@
+package exec
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/okalexiiis/dwrk/internal/config"
+	"github.com/okalexiiis/dwrk/internal/project"
+	"github.com/okalexiiis/dwrk/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	filterName string
+	tags       []string
+	showHidden bool
+	parallel   bool
+	jobs       int
+	group      bool
+)
+
+// ExecCmd defines the `dwrk exec` command.
+//
+// It runs a command in the directory of every selected project and reports
+// the exit code of each run.
+var ExecCmd = &cobra.Command{
+	Use:   "exec [flags] -- <command> [args...]",
+	Short: "Run a command in several projects",
+	Long: `Run a command in the directory of every project matching the filters.
+
+Projects are selected with --filter (substring of the name) and --tag
+(registered tags; repeat to require several). Without filters the command
+runs in every project.
+
+The command is executed directly, not through a shell; use
+'-- sh -c "..."' for pipes or variables. Output lines are prefixed with the
+project name, or printed as one block per project with --group. Runs are
+sequential unless --parallel is given. The command exits with a non-zero
+status if it failed in any project.
+
+Examples:
+  dwrk exec --filter api -- go mod tidy
+  dwrk exec --tag backend --parallel -- git checkout main
+  dwrk exec --group -- sh -c 'git log -1 --oneline'`,
+	Args: cobra.MinimumNArgs(1),
+	Run:  runExec,
+}
+
+// execResult is the outcome of running the command in one project.
+type execResult struct {
+	name     string
+	exitCode int
+	err      error // Set when the command could not be started
+}
+
+func init() {
+	ExecCmd.Flags().StringVarP(&filterName, "filter", "f", "", "Only projects whose name contains this text")
+	ExecCmd.Flags().StringSliceVarP(&tags, "tag", "t", nil, "Only projects with this tag (repeatable)")
+	ExecCmd.Flags().BoolVar(&showHidden, "hidden", false, "Include hidden folders")
+	ExecCmd.Flags().BoolVarP(&parallel, "parallel", "p", false, "Run in several projects at once")
+	ExecCmd.Flags().IntVarP(&jobs, "jobs", "j", 4, "Maximum number of parallel runs (with --parallel)")
+	ExecCmd.Flags().BoolVarP(&group, "group", "g", false, "Print each project's output as one block instead of prefixing lines")
+
+	// Flags after the command belong to it, even without "--".
+	ExecCmd.Flags().SetInterspersed(false)
+}
+
+func runExec(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	manager := project.NewManager(cfg)
+	projects, err := manager.List(project.ListOptions{
+		ShowHidden: showHidden,
+		Filter:     filterName,
+		Tags:       tags,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing projects: %v\n", err)
+		os.Exit(1)
+	}
+
+	var selected []project.Project
+	for _, proj := range projects {
+		if !proj.Missing {
+			selected = append(selected, proj)
+		}
+	}
+	if len(selected) == 0 {
+		fmt.Println("No projects match the given filters")
+		return
+	}
+
+	width := 0
+	for _, proj := range selected {
+		width = max(width, len(proj.Name))
+	}
+
+	limit := 1
+	if parallel {
+		limit = jobs
+	}
+
+	var mu sync.Mutex
+	results := make([]execResult, len(selected))
+	utils.Parallel(len(selected), limit, func(i int) {
+		results[i] = runIn(selected[i], args, &mu, width)
+	})
+
+	if failed := printSummary(results); failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// runIn runs the command in a project directory. Output goes through mu so
+// that lines (or blocks, with --group) of different projects do not mix.
+func runIn(proj project.Project, args []string, mu *sync.Mutex, width int) execResult {
+	result := execResult{name: proj.Name}
+
+	c := exec.Command(args[0], args[1:]...)
+	c.Dir = proj.Path
+
+	var err error
+	if group {
+		var output bytes.Buffer
+		c.Stdout = &output
+		c.Stderr = &output
+		err = c.Run()
+
+		mu.Lock()
+		fmt.Printf("==> %s <==\n", proj.Name)
+		os.Stdout.Write(output.Bytes())
+		if output.Len() > 0 && !bytes.HasSuffix(output.Bytes(), []byte("\n")) {
+			fmt.Println()
+		}
+		fmt.Println()
+		mu.Unlock()
+	} else {
+		prefix := fmt.Sprintf("%-*s | ", width, proj.Name)
+		stdout := &prefixWriter{mu: mu, out: os.Stdout, prefix: prefix}
+		stderr := &prefixWriter{mu: mu, out: os.Stderr, prefix: prefix}
+		c.Stdout = stdout
+		c.Stderr = stderr
+		err = c.Run()
+		stdout.Flush()
+		stderr.Flush()
+	}
+
+	var exitErr *exec.ExitError
+	switch {
+	case err == nil:
+	case errors.As(err, &exitErr):
+		result.exitCode = exitErr.ExitCode()
+	default:
+		result.exitCode = -1
+		result.err = err
+	}
+
+	return result
+}
+
+// printSummary prints the exit code of every project that failed followed by
+// the totals, and returns the number of failures.
+func printSummary(results []execResult) int {
+	var failed []execResult
+	for _, r := range results {
+		if r.exitCode != 0 {
+			failed = append(failed, r)
+		}
+	}
+
+	if len(failed) > 0 {
+		fmt.Println()
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "PROJECT\tEXIT")
+		for _, r := range failed {
+			if r.err != nil {
+				fmt.Fprintf(w, "%s\t%s\n", r.name, r.err)
+			} else {
+				fmt.Fprintf(w, "%s\t%d\n", r.name, r.exitCode)
+			}
+		}
+		w.Flush()
+	}
+
+	fmt.Printf("\n%d succeeded, %d failed (of %d projects)\n", len(results)-len(failed), len(failed), len(results))
+	return len(failed)
+}
+
+// prefixWriter writes complete lines to out, each preceded by prefix.
+type prefixWriter struct {
+	mu     *sync.Mutex
+	out    io.Writer
+	prefix string
+	buf    []byte
+}
+
+func (w *prefixWriter) Write(p []byte) (int, error) {
+	w.buf = append(w.buf, p...)
+
+	for {
+		i := bytes.IndexByte(w.buf, '\n')
+		if i < 0 {
+			break
+		}
+		w.writeLine(string(w.buf[:i]))
+		w.buf = w.buf[i+1:]
+	}
+
+	return len(p), nil
+}
+
+// Flush writes any trailing output that did not end with a newline.
+func (w *prefixWriter) Flush() {
+	if len(w.buf) > 0 {
+		w.writeLine(string(w.buf))
+		w.buf = nil
+	}
+}
+
+func (w *prefixWriter) writeLine(line string) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	fmt.Fprintf(w.out, "%s%s\n", w.prefix, strings.TrimSuffix(line, "\r"))
+}
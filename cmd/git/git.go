@@ -0,0 +1,246 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/okalexiiis/dwrk/internal/config"
+	"github.com/okalexiiis/dwrk/internal/git"
+	"github.com/okalexiiis/dwrk/internal/project"
+	"github.com/okalexiiis/dwrk/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// flags
+var (
+	all     bool
+	yes     bool
+	fetch   bool
+	jobs    int
+	timeout time.Duration
+)
+
+// GitCmd groups Git maintenance commands that work across projects.
+var GitCmd = &cobra.Command{
+	Use:   "git",
+	Short: "Git maintenance across projects",
+	Long: `Git maintenance commands that work on one or many projects.
+
+Subcommands:
+  prune-branches   Delete local branches that are merged or whose upstream is gone`,
+}
+
+var pruneBranchesCmd = &cobra.Command{
+	Use:   "prune-branches [project|--all]",
+	Short: "Delete local branches that are merged or whose upstream is gone",
+	Long: `Find local branches that are already merged into the default branch or
+whose upstream branch was deleted from the remote ("gone"), and delete them.
+
+The default branch is the one origin/HEAD points to, or else main or master.
+The default branch and branches checked out in a worktree (including the
+current one) are never touched. Gone branches are deleted even if Git does not
+consider them merged, since squash and rebase merges leave them that way.
+
+Without --yes the branches are only listed; when run from a terminal you are
+asked before anything is deleted. Use --fetch to refresh remote branches first,
+otherwise "gone" reflects the last fetch with --prune (e.g. dwrk sync).
+
+Examples:
+  dwrk git prune-branches api
+  dwrk git prune-branches --all --fetch
+  dwrk git prune-branches --all --yes`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runPruneBranches,
+}
+
+// repoBranches are the stale branches found in one project.
+type repoBranches struct {
+	proj     project.Project
+	base     string
+	branches []git.StaleBranch
+	err      error
+}
+
+func init() {
+	pruneBranchesCmd.Flags().BoolVarP(&all, "all", "a", false, "Prune every Git project")
+	pruneBranchesCmd.Flags().BoolVarP(&yes, "yes", "y", false, "Delete the branches without asking")
+	pruneBranchesCmd.Flags().BoolVar(&fetch, "fetch", false, "Run 'git fetch --prune' before looking for branches")
+	pruneBranchesCmd.Flags().IntVarP(&jobs, "jobs", "j", 4, "Number of repositories inspected in parallel")
+	pruneBranchesCmd.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "Time limit for each repository")
+
+	GitCmd.AddCommand(pruneBranchesCmd)
+}
+
+func runPruneBranches(cmd *cobra.Command, args []string) {
+	if all == (len(args) > 0) {
+		fmt.Fprintln(os.Stderr, "Error: specify a project name or --all")
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	repos, err := selectProjects(project.NewManager(cfg), args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(repos) == 0 {
+		fmt.Println("No Git projects found")
+		return
+	}
+
+	found := make([]repoBranches, len(repos))
+	utils.Parallel(len(repos), jobs, func(i int) {
+		ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+		defer cancel()
+
+		found[i] = findBranches(ctx, repos[i])
+	})
+
+	total, failed := printBranches(found)
+	if total == 0 {
+		fmt.Println("No branches to prune")
+		exitOnFailure(failed)
+		return
+	}
+
+	if !yes {
+		if !utils.IsTerminal(os.Stdin) {
+			fmt.Printf("\n%d branch(es) would be deleted. Run again with --yes to delete them.\n", total)
+			exitOnFailure(failed)
+			return
+		}
+
+		fmt.Printf("\nDelete %d branch(es)? [y/N]: ", total)
+
+		var response string
+		fmt.Scanln(&response)
+
+		if response != "y" && response != "Y" && response != "yes" {
+			fmt.Println("Operation cancelled")
+			exitOnFailure(failed)
+			return
+		}
+	}
+
+	fmt.Println()
+	deleted := 0
+	for _, r := range found {
+		for _, branch := range r.branches {
+			if err := git.DeleteBranch(cmd.Context(), r.proj.Path, branch.Name); err != nil {
+				fmt.Fprintf(os.Stderr, "Error deleting %s in %s: %v\n", branch.Name, r.proj.Name, err)
+				failed++
+				continue
+			}
+			fmt.Printf("Deleted %s in %s\n", branch.Name, r.proj.Name)
+			deleted++
+		}
+	}
+
+	fmt.Printf("\n%d branch(es) deleted\n", deleted)
+	exitOnFailure(failed)
+}
+
+// selectProjects returns the named project, or every Git project when no
+// name is given.
+func selectProjects(manager *project.Manager, names []string) ([]project.Project, error) {
+	if len(names) == 0 {
+		projects, err := manager.List(project.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		var repos []project.Project
+		for _, proj := range projects {
+			if proj.IsGit && !proj.Missing {
+				repos = append(repos, proj)
+			}
+		}
+		return repos, nil
+	}
+
+	proj, err := manager.GetExact(names[0])
+	if err != nil {
+		return nil, err
+	}
+	if proj.Missing {
+		return nil, fmt.Errorf("project '%s' is registered at %s but the directory no longer exists", proj.Name, proj.Path)
+	}
+	if !proj.IsGit {
+		return nil, fmt.Errorf("project '%s' is not a Git repository", proj.Name)
+	}
+	return []project.Project{*proj}, nil
+}
+
+// findBranches looks up the default branch of a project and the branches that
+// can be pruned.
+func findBranches(ctx context.Context, proj project.Project) repoBranches {
+	r := repoBranches{proj: proj}
+
+	if fetch {
+		if err := git.Fetch(ctx, proj.Path); err != nil {
+			r.err = fmt.Errorf("fetch: %w", err)
+			return r
+		}
+	}
+
+	r.base = git.DefaultBranch(ctx, proj.Path)
+	r.branches, r.err = git.StaleBranches(ctx, proj.Path, r.base)
+	return r
+}
+
+// printBranches lists the stale branches of every project that has any, and
+// returns the number of branches and of projects that could not be inspected.
+func printBranches(found []repoBranches) (total, failed int) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+
+	for _, r := range found {
+		if r.err != nil {
+			fmt.Fprintf(os.Stderr, "Error in %s: %v\n", r.proj.Name, r.err)
+			failed++
+			continue
+		}
+		if len(r.branches) == 0 {
+			continue
+		}
+
+		base := r.base
+		if base == "" {
+			base = "unknown, only gone branches"
+		}
+		fmt.Fprintf(w, "%s (default: %s)\n", r.proj.Name, base)
+		for _, branch := range r.branches {
+			fmt.Fprintf(w, "  %s\t%s\n", branch.Name, reason(branch))
+		}
+		total += len(r.branches)
+	}
+
+	w.Flush()
+	return total, failed
+}
+
+// reason describes why a branch can be pruned.
+func reason(branch git.StaleBranch) string {
+	var reasons []string
+	if branch.Merged {
+		reasons = append(reasons, "merged")
+	}
+	if branch.Gone {
+		reasons = append(reasons, "upstream gone")
+	}
+	return strings.Join(reasons, ", ")
+}
+
+func exitOnFailure(failed int) {
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,80 @@
+package gitignore
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/okalexiiis/dwrk/internal/catalog"
+	"github.com/okalexiiis/dwrk/internal/config"
+	"github.com/okalexiiis/dwrk/internal/project"
+	"github.com/spf13/cobra"
+)
+
+// GitignoreCmd groups the commands that manage .gitignore files from the
+// built-in catalog.
+var GitignoreCmd = &cobra.Command{
+	Use:   "gitignore",
+	Short: "Add .gitignore templates to projects",
+	Long: `Add .gitignore templates from the built-in catalog to projects.
+
+Subcommands:
+  list   List the available templates
+  add    Merge templates into a project's .gitignore`,
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the available templates",
+	Args:  cobra.NoArgs,
+	Run:   runList,
+}
+
+var addCmd = &cobra.Command{
+	Use:   "add <project> <template>...",
+	Short: "Merge templates into a project's .gitignore",
+	Long: `Merge .gitignore templates into a project, creating the file if needed.
+
+Each template is added as a "### <name> ###" section. Templates already in the
+file are skipped and patterns the file already has are not repeated, so the
+command can be run again safely.
+
+Examples:
+  dwrk gitignore add api go macos
+  dwrk gitignore add web node vscode`,
+	Args: cobra.MinimumNArgs(2),
+	Run:  runAdd,
+}
+
+func init() {
+	GitignoreCmd.AddCommand(listCmd)
+	GitignoreCmd.AddCommand(addCmd)
+}
+
+func runList(cmd *cobra.Command, args []string) {
+	fmt.Println("Available .gitignore templates:")
+	fmt.Println()
+	for _, name := range catalog.Gitignores() {
+		fmt.Printf("  %s\n", name)
+	}
+}
+
+func runAdd(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	added, err := project.NewManager(cfg).AddGitignore(args[0], args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(added) == 0 {
+		fmt.Println(".gitignore already contains these templates")
+		return
+	}
+	fmt.Printf("Added to .gitignore: %s\n", strings.Join(added, ", "))
+}
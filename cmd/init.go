@@ -1,11 +1,20 @@
 package cmd
 
 import (
+	"github.com/okalexiiis/dwrk/cmd/add"
 	"github.com/okalexiiis/dwrk/cmd/clone"
 	"github.com/okalexiiis/dwrk/cmd/config"
+	"github.com/okalexiiis/dwrk/cmd/exec"
+	"github.com/okalexiiis/dwrk/cmd/git"
+	"github.com/okalexiiis/dwrk/cmd/gitignore"
+	"github.com/okalexiiis/dwrk/cmd/license"
 	"github.com/okalexiiis/dwrk/cmd/list"
 	"github.com/okalexiiis/dwrk/cmd/new"
 	"github.com/okalexiiis/dwrk/cmd/open"
+	"github.com/okalexiiis/dwrk/cmd/status"
+	"github.com/okalexiiis/dwrk/cmd/sync"
+	"github.com/okalexiiis/dwrk/cmd/template"
+	"github.com/okalexiiis/dwrk/cmd/worktree"
 )
 
 func init() {
@@ -14,4 +23,13 @@ func init() {
 	RootCmd.AddCommand(open.OpenCmd)
 	RootCmd.AddCommand(clone.CloneCmd)
 	RootCmd.AddCommand(config.ConfigCmd)
+	RootCmd.AddCommand(add.AddCmd)
+	RootCmd.AddCommand(template.TemplateCmd)
+	RootCmd.AddCommand(status.StatusCmd)
+	RootCmd.AddCommand(sync.SyncCmd)
+	RootCmd.AddCommand(exec.ExecCmd)
+	RootCmd.AddCommand(git.GitCmd)
+	RootCmd.AddCommand(worktree.WorktreeCmd)
+	RootCmd.AddCommand(gitignore.GitignoreCmd)
+	RootCmd.AddCommand(license.LicenseCmd)
 }
@@ -0,0 +1,90 @@
+package license
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/okalexiiis/dwrk/internal/catalog"
+	"github.com/okalexiiis/dwrk/internal/config"
+	"github.com/okalexiiis/dwrk/internal/project"
+	"github.com/spf13/cobra"
+)
+
+// flags
+var (
+	holderFlag string
+	yearFlag   int
+)
+
+// LicenseCmd groups the commands that manage project licenses from the
+// built-in catalog.
+var LicenseCmd = &cobra.Command{
+	Use:   "license",
+	Short: "Set the license of projects",
+	Long: `Write licenses from the built-in catalog to projects.
+
+Subcommands:
+  list   List the available licenses
+  set    Write or replace a project's license`,
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the available licenses",
+	Args:  cobra.NoArgs,
+	Run:   runList,
+}
+
+var setCmd = &cobra.Command{
+	Use:   "set <project> <spdx-id>",
+	Short: "Write or replace a project's license",
+	Long: `Write a license to a project, replacing its current license file
+(LICENSE, LICENSE.md, LICENSE.txt or COPYING) or creating LICENSE.
+
+The identifier is an SPDX license identifier, in any case. The copyright
+holder defaults to license_holder, then to the project's Git user.name and
+finally to github_username; the year defaults to the current one.
+
+Examples:
+  dwrk license set api apache-2.0
+  dwrk license set api MIT --holder "Our Company" --year 2021`,
+	Args: cobra.ExactArgs(2),
+	Run:  runSet,
+}
+
+func init() {
+	setCmd.Flags().StringVar(&holderFlag, "holder", "", "Copyright holder")
+	setCmd.Flags().IntVar(&yearFlag, "year", 0, "Copyright year (default: current year)")
+
+	LicenseCmd.AddCommand(listCmd)
+	LicenseCmd.AddCommand(setCmd)
+}
+
+func runList(cmd *cobra.Command, args []string) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "ID\tNAME")
+	for _, l := range catalog.Licenses() {
+		fmt.Fprintf(w, "%s\t%s\n", l.ID, l.Name)
+	}
+	w.Flush()
+}
+
+func runSet(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	path, err := project.NewManager(cfg).SetLicense(args[0], args[1], project.LicenseOptions{
+		Holder: holderFlag,
+		Year:   yearFlag,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("License written: %s\n", path)
+}
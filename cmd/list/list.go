@@ -3,6 +3,8 @@ package list
 import (
 	"fmt"
 	"os"
+	"path/filepath"
+	"strings"
 
 	"github.com/okalexiiis/dwrk/internal/config"
 	"github.com/okalexiiis/dwrk/internal/project"
@@ -12,23 +14,25 @@ import (
 var (
 	showHidden bool
 	filterName string
+	search     string
 )
 
 // ListCmd defines the `dwrk list` command.
 //
 // This command displays all local projects found in the configured
-// projects directory. It supports filtering by name and optionally
+// project roots and in the project registry. It supports filtering by name and optionally
 // displaying hidden folders.
 var ListCmd = &cobra.Command{
 	Use:   "list",
 	Short: "List all local projects",
-	Long:  `List all projects located in the configured projects directory.`,
+	Long:  `List all projects located in the configured project roots (projects_dir and projects_dirs).`,
 	Run:   runList,
 }
 
 func init() {
 	ListCmd.Flags().BoolVarP(&showHidden, "all", "a", false, "Show hidden folders")
 	ListCmd.Flags().StringVarP(&filterName, "filter", "f", "", "Filter projects by name")
+	ListCmd.Flags().StringVarP(&search, "search", "s", "", "Fuzzy search projects by name, best match first")
 }
 
 // runList executes the logic of the `list` command.
@@ -47,11 +51,13 @@ func runList(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	manager := project.NewManager(cfg.ProjectsDir)
+	manager := project.NewManager(cfg)
+	roots := strings.Join(manager.Roots(), ", ")
 
 	projects, err := manager.List(project.ListOptions{
 		ShowHidden: showHidden,
 		Filter:     filterName,
+		Search:     search,
 	})
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error listing projects: %v\n", err)
@@ -59,20 +65,35 @@ func runList(cmd *cobra.Command, args []string) {
 	}
 
 	if len(projects) == 0 {
-		fmt.Printf("No projects found in: %s\n", cfg.ProjectsDir)
+		fmt.Printf("No projects found in: %s\n", roots)
 		fmt.Println("\nTip: Create a new project using:")
 		fmt.Println("   dwrk new my-project")
 		return
 	}
 
-	fmt.Printf("Projects in %s:\n\n", cfg.ProjectsDir)
+	fmt.Printf("Projects in %s:\n\n", roots)
 	for i, proj := range projects {
 		gitIndicator := ""
 		if proj.IsGit {
 			gitIndicator = " 🔗"
 		}
+		if proj.Missing {
+			gitIndicator += fmt.Sprintf(" (missing: %s)", proj.Path)
+		} else if !isDirectChild(proj.Path, manager.Roots()) {
+			gitIndicator += fmt.Sprintf(" → %s", proj.Path)
+		}
 		fmt.Printf("  %d. %s%s\n", i+1, proj.Name, gitIndicator)
 	}
 
 	fmt.Printf("\nTotal: %d project(s)\n", len(projects))
 }
+
+// isDirectChild reports whether path sits directly under one of the roots.
+func isDirectChild(path string, roots []string) bool {
+	for _, root := range roots {
+		if filepath.Dir(path) == root {
+			return true
+		}
+	}
+	return false
+}
@@ -14,19 +14,91 @@ import (
 var (
 	git      bool
 	template string
+	vars     []string
+	noHooks  bool
+	remote   string
+	org      string
+
+	branch    string
+	gitignore []string
+	license   string
+	host      string
+	noCommit  bool
 )
 
 var NewCmd = &cobra.Command{
 	Use:   "new <name>",
 	Short: "Create a new local project",
-	Long:  "Creates a new local project in the configured directory and optionally initializes a Git repository.",
-	Args:  cobra.ExactArgs(1),
-	Run:   runNew,
+	Long: `Creates a new local project in the configured directory and optionally initializes a Git repository.
+
+Templates are looked up in templates_dir. Every file and file name of the
+template is rendered with Go's text/template, with access to {{.ProjectName}},
+{{.GitHubUsername}}, {{.ModulePath}}, {{.Date}}, {{.Year}} and the values
+passed with --var as {{.Vars.key}}.
+
+Variables declared in the template's template.yaml are prompted for when
+missing. Without a terminal, defaults are used and a missing required
+variable is an error. Commands listed under post_create in the manifest run
+in the new project afterwards; use --no-hooks to skip them. If a hook fails
+the project directory is removed.
+
+Templates can also be fetched from a Git repository as
+<repo>[//<subdir>][@<ref>]; they are cached in ~/.cache/dwrk/templates and
+the resolved commit is recorded in the project registry.
+
+With --remote, a GitHub repository is created for the project (under
+github_username, or --org), added as origin using the use_ssh preference, and
+the initial commit is pushed. The repository is private unless --remote=public
+is given. It implies --git and needs a token in github_token or GITHUB_TOKEN.
+
+The repository gets a README.md and, when configured or requested, a
+.gitignore combined from the built-in catalog (--gitignore go,macos) and a
+LICENSE filled in with the holder and year (--license MIT). Files provided by
+the template are kept. Commits use the identity of --host (or default_host)
+from the configuration, falling back to git_user_name/git_user_email and
+then to Git's own settings. Without any user.email the initial commit is
+skipped instead of failing; --no-commit always skips it. These flags imply
+--git and override the init_* configuration keys.
+
+Examples:
+  dwrk new api-server -g
+  dwrk new api-server --branch main --gitignore go,macos --license Apache-2.0
+  dwrk new client-work -g --host work
+  dwrk new api-server -t go-service --var port=8080
+  dwrk new api-server -t github.com/our-org/templates//go-service@v1.4
+  dwrk new api-server --remote
+  dwrk new api-server --remote=public --org our-org`,
+	Args: newArgs,
+	Run:  runNew,
 }
 
 func init() {
 	NewCmd.Flags().BoolVarP(&git, "git", "g", false, "Initialize a Git repository")
 	NewCmd.Flags().StringVarP(&template, "template", "t", "", "Create a Project with a template")
+	NewCmd.Flags().StringArrayVar(&vars, "var", nil, "Template variable as key=value (repeatable)")
+	NewCmd.Flags().BoolVar(&noHooks, "no-hooks", false, "Do not run the template's post_create commands")
+	NewCmd.Flags().StringVar(&remote, "remote", "", "Create the GitHub repository and push to it (public or private)")
+	NewCmd.Flags().Lookup("remote").NoOptDefVal = "private"
+	NewCmd.Flags().StringVar(&org, "org", "", "GitHub organization that owns the remote repository")
+	NewCmd.Flags().StringVar(&branch, "branch", "", "Initial branch name (default: init_branch or Git's default)")
+	NewCmd.Flags().StringSliceVar(&gitignore, "gitignore", nil, "Catalog .gitignore templates to combine (e.g. go,macos)")
+	NewCmd.Flags().StringVar(&license, "license", "", "SPDX identifier of the license to add (e.g. MIT, Apache-2.0)")
+	NewCmd.Flags().StringVar(&host, "host", "", "Host whose commit identity is used (default: default_host)")
+	NewCmd.Flags().BoolVar(&noCommit, "no-commit", false, "Do not create the initial commit")
+}
+
+// newArgs accepts the project name only. Since the visibility of --remote is
+// optional it must be attached with '=', so "--remote public" is reported
+// instead of being taken as a second argument.
+func newArgs(cmd *cobra.Command, args []string) error {
+	if len(args) == 2 && cmd.Flags().Changed("remote") {
+		for _, arg := range args {
+			if arg == "public" || arg == "private" {
+				return fmt.Errorf("use --remote=%s to set the repository visibility", arg)
+			}
+		}
+	}
+	return cobra.ExactArgs(1)(cmd, args)
 }
 
 func runNew(cmd *cobra.Command, args []string) {
@@ -38,15 +110,39 @@ func runNew(cmd *cobra.Command, args []string) {
 	}
 
 	projectName := args[0]
-	projectsDir := utils.ExpandPath(cfg.ProjectsDir)
+
+	templateVars, err := utils.ParseVars(vars)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	// A remote repository needs a local one to push, and the repository
+	// options only make sense with one
+	if remote != "" || branch != "" || gitignore != nil || license != "" || host != "" || noCommit {
+		git = true
+	}
 
 	// Create project manager
-	manager := project.NewManager(projectsDir)
+	manager := project.NewManager(cfg)
 
 	// Attempt to create the project
 	createdProject, err := manager.Create(projectName, project.CreateOptions{
 		InitGit:  git,
 		Template: template,
+		Vars:     templateVars,
+
+		Interactive: utils.IsTerminal(os.Stdin),
+		NoHooks:     noHooks,
+
+		Remote:    remote,
+		RemoteOrg: org,
+
+		Branch:    branch,
+		Gitignore: gitignore,
+		License:   license,
+		Host:      host,
+		NoCommit:  noCommit,
 	})
 
 	if err != nil {
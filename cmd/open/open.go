@@ -1,6 +1,7 @@
 package open
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -9,6 +10,7 @@ import (
 	"github.com/okalexiiis/dwrk/internal/editor"
 	options "github.com/okalexiiis/dwrk/internal/editor/editors"
 	"github.com/okalexiiis/dwrk/internal/project"
+	"github.com/okalexiiis/dwrk/pkg/utils"
 	"github.com/spf13/cobra"
 )
 
@@ -18,10 +20,29 @@ var (
 )
 
 var OpenCmd = &cobra.Command{
-	Use:   "open <name>",
+	Use:   "open [name]",
 	Short: "Open a project",
-	Args:  cobra.ExactArgs(1),
-	Run:   runOpen,
+	Long: `Open a project in an editor, in tmux or in a new shell.
+
+The name does not have to be exact: when no project has that name, projects
+are matched fuzzily (e.g. "apisrv" opens "api-server"). If several projects
+match, you are asked to pick one of them, best match first.
+
+Without a name, a full-screen picker lists every project. Type to filter,
+move with the arrow keys (or Ctrl+P/Ctrl+N) and the pane on the right shows
+the path, branch, uncommitted changes, last modification and README of the
+selected project. Keys:
+
+  Enter    open as without the picker (--editor, --tmux or default_editor)
+  Ctrl+E   open in the editor
+  Ctrl+T   open in tmux
+  Ctrl+O   open a shell in the project
+  Ctrl+Y   copy the project path to the clipboard
+  Esc      quit
+
+When the output is not a terminal, a numbered list is printed instead.`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runOpen,
 }
 
 func init() {
@@ -30,8 +51,6 @@ func init() {
 }
 
 func runOpen(cmd *cobra.Command, args []string) {
-	projectName := args[0]
-
 	// Load configuration
 	cfg, err := config.Load()
 	if err != nil {
@@ -39,16 +58,48 @@ func runOpen(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	// Ensure the project exists
-	manager := project.NewManager(cfg.ProjectsDir)
-	proj, err := manager.Get(projectName)
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-		fmt.Println("\nAvailable projects:")
-		fmt.Println("  dwrk list")
+	manager := project.NewManager(cfg)
+
+	var proj *project.Project
+	act := actionDefault
+	if len(args) == 0 {
+		proj, act = pickProject(manager)
+	} else {
+		proj = findProject(manager, args[0])
+	}
+
+	if proj.Missing {
+		fmt.Fprintf(os.Stderr, "Error: project '%s' is registered at %s but the directory no longer exists\n", proj.Name, proj.Path)
 		os.Exit(1)
 	}
 
+	switch act {
+	case actionCopy:
+		if err := utils.CopyToClipboard(proj.Path); err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Copied path of '%s': %s\n", proj.Name, proj.Path)
+		return
+	case actionTmux:
+		openWith(options.NewTmux(), proj)
+		return
+	case actionShell:
+		openShell(proj)
+		return
+	case actionEditor:
+		selectedEditorName := editorFlag
+		if selectedEditorName == "" {
+			selectedEditorName = cfg.DefaultEditor
+		}
+		if selectedEditorName == "" || selectedEditorName == "auto" {
+			openWith(editor.GetDefault(), proj)
+			return
+		}
+		openWith(getEditor(selectedEditorName), proj)
+		return
+	}
+
 	// Determine which editor to use
 	selectedEditorName := editorFlag
 	if selectedEditorName == "" && !tmuxFlag {
@@ -56,38 +107,117 @@ func runOpen(cmd *cobra.Command, args []string) {
 	}
 
 	if tmuxFlag {
-		selectedEditor := options.NewTmux()
-		fmt.Printf("Opening '%s' with %s...\n", projectName, selectedEditor.Name())
-
-		if err := selectedEditor.Open(proj.Path); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
-		}
-
-		fmt.Println("Project opened successfully")
+		openWith(options.NewTmux(), proj)
 		return
 	}
 
 	if selectedEditorName != "" && selectedEditorName != "auto" {
-		selectedEditor, err := editor.GetEditor(selectedEditorName)
-		if err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		openWith(getEditor(selectedEditorName), proj)
+		return
+	}
+
+	// Default: open a shell session
+	openShell(proj)
+}
+
+// findProject returns the project matching name, asking the user to pick one
+// when several match fuzzily. It exits when no project can be chosen.
+func findProject(manager *project.Manager, name string) *project.Project {
+	proj, err := manager.Find(name)
+
+	// Let the user pick among several fuzzy matches
+	var ambiguous *project.AmbiguousError
+	if errors.As(err, &ambiguous) && utils.IsTerminal(os.Stdin) {
+		i := utils.Choose(os.Stdin, os.Stdout, fmt.Sprintf("Several projects match '%s':", name), describe(ambiguous.Candidates))
+		if i < 0 {
+			fmt.Println("Operation cancelled")
 			os.Exit(1)
 		}
+		proj, err = &ambiguous.Candidates[i], nil
+	}
 
-		fmt.Printf("Opening '%s' with %s...\n", projectName, selectedEditor.Name())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		fmt.Println("\nAvailable projects:")
+		fmt.Println("  dwrk list")
+		os.Exit(1)
+	}
 
-		if err := selectedEditor.Open(proj.Path); err != nil {
-			fmt.Fprintf(os.Stderr, "Error: %v\n", err)
-			os.Exit(1)
+	if proj.Name != name && ambiguous == nil {
+		fmt.Printf("Using '%s' for '%s'\n", proj.Name, name)
+	}
+	return proj
+}
+
+// pickProject lets the user choose among every project, with the full-screen
+// picker on a terminal or a numbered prompt otherwise. It exits when the user
+// cancels.
+func pickProject(manager *project.Manager) (*project.Project, action) {
+	projects, err := manager.List(project.ListOptions{})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(projects) == 0 {
+		fmt.Fprintln(os.Stderr, "Error: no projects found")
+		os.Exit(1)
+	}
+
+	if utils.IsTerminal(os.Stdin) && utils.IsTerminal(os.Stdout) {
+		proj, act, err := pick(projects)
+		if err == nil {
+			if proj == nil {
+				fmt.Println("Operation cancelled")
+				os.Exit(1)
+			}
+			return proj, act
 		}
+		// Without a usable terminal, fall back to the numbered prompt.
+	}
 
-		fmt.Println("Project opened successfully")
-		return
+	// The prompt goes to stderr, since stdout may be redirected.
+	i := utils.Choose(os.Stdin, os.Stderr, "Projects:", describe(projects))
+	if i < 0 {
+		fmt.Fprintln(os.Stderr, "Operation cancelled")
+		os.Exit(1)
 	}
+	return &projects[i], actionDefault
+}
 
-	// Default: open a shell session
-	fmt.Printf("Opening shell in '%s'...\n", projectName)
+// describe returns the name and path of each project, as listed in prompts.
+func describe(projects []project.Project) []string {
+	options := make([]string, len(projects))
+	for i, proj := range projects {
+		options[i] = fmt.Sprintf("%s (%s)", proj.Name, proj.Path)
+	}
+	return options
+}
+
+// getEditor returns the named editor, exiting if it is unknown or missing.
+func getEditor(name string) editor.Editor {
+	selectedEditor, err := editor.GetEditor(name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	return selectedEditor
+}
+
+// openWith opens the project with the given editor, exiting on failure.
+func openWith(selectedEditor editor.Editor, proj *project.Project) {
+	fmt.Printf("Opening '%s' with %s...\n", proj.Name, selectedEditor.Name())
+
+	if err := selectedEditor.Open(proj.Path); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("Project opened successfully")
+}
+
+// openShell starts $SHELL in the project directory and waits for it to exit.
+func openShell(proj *project.Project) {
+	fmt.Printf("Opening shell in '%s'...\n", proj.Name)
 
 	shell := os.Getenv("SHELL")
 	if shell == "" {
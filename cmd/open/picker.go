@@ -0,0 +1,439 @@
+package open
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"syscall"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/okalexiiis/dwrk/internal/project"
+	"golang.org/x/term"
+)
+
+// action is what to do with the project chosen in the picker.
+type action int
+
+const (
+	actionDefault action = iota // Open as without the picker: --editor, --tmux or default_editor
+	actionEditor                // Open in the editor, detecting one if none is configured
+	actionTmux                  // Open in tmux
+	actionShell                 // Open a shell in the project directory
+	actionCopy                  // Copy the project path to the clipboard
+)
+
+// ctrlActions maps the Ctrl+letter bindings of the picker to actions.
+var ctrlActions = map[rune]action{
+	'e': actionEditor,
+	't': actionTmux,
+	'o': actionShell,
+	'y': actionCopy,
+}
+
+const pickerHelp = "enter open  ^E editor  ^T tmux  ^O shell  ^Y copy path  esc quit"
+
+// errNoTTY is returned by pick when the controlling terminal cannot be opened.
+var errNoTTY = errors.New("no terminal available")
+
+// keyKind identifies a key read by the picker.
+type keyKind int
+
+const (
+	keyRune keyKind = iota
+	keyCtrl
+	keyEnter
+	keyEsc
+	keyBackspace
+	keyUp
+	keyDown
+	keyPageUp
+	keyPageDown
+)
+
+// key is a key press. r holds the character for keyRune and the letter for
+// keyCtrl.
+type key struct {
+	kind keyKind
+	r    rune
+}
+
+// picker is the state of the full-screen project picker.
+type picker struct {
+	tty      *os.File
+	all      []project.Project
+	matches  []project.Project
+	query    []rune
+	selected int
+	offset   int // Index of the first visible match
+	width    int
+	height   int
+
+	previews map[string][]string
+	loading  map[string]bool
+	loaded   chan preview
+	done     chan struct{} // Closed when the picker exits
+}
+
+// pick shows the full-screen picker over projects on the controlling terminal
+// and returns the chosen project and action. It returns a nil project when the
+// user cancels, and errNoTTY when no terminal can be used.
+func pick(projects []project.Project) (*project.Project, action, error) {
+	tty, err := os.OpenFile("/dev/tty", os.O_RDWR, 0)
+	if err != nil {
+		return nil, actionDefault, errNoTTY
+	}
+	defer tty.Close()
+
+	// The descriptor is used through SyscallConn rather than Fd, which would
+	// make it blocking and keep Close from interrupting the pending read.
+	conn, err := tty.SyscallConn()
+	if err != nil {
+		return nil, actionDefault, errNoTTY
+	}
+	var state *term.State
+	conn.Control(func(fd uintptr) {
+		state, err = term.MakeRaw(int(fd))
+	})
+	if err != nil {
+		return nil, actionDefault, errNoTTY
+	}
+	defer conn.Control(func(fd uintptr) {
+		term.Restore(int(fd), state)
+	})
+
+	p := &picker{
+		tty:      tty,
+		all:      projects,
+		previews: map[string][]string{},
+		loading:  map[string]bool{},
+		loaded:   make(chan preview),
+		done:     make(chan struct{}),
+	}
+	p.filter()
+	p.resize(conn)
+
+	// Alternate screen, hidden cursor; both are undone before returning.
+	fmt.Fprint(tty, "\x1b[?1049h\x1b[?25l")
+	defer fmt.Fprint(tty, "\x1b[?25h\x1b[?1049l")
+
+	defer close(p.done)
+	keys := make(chan []byte)
+	go readKeys(tty, keys, p.done)
+
+	// Terminal size changes are picked up by polling, which works the same on
+	// every platform.
+	ticker := time.NewTicker(200 * time.Millisecond)
+	defer ticker.Stop()
+
+	redraw := true
+	for {
+		if redraw {
+			p.loadPreview()
+			p.draw()
+		}
+		redraw = true
+
+		select {
+		case input, ok := <-keys:
+			if !ok {
+				return nil, actionDefault, nil
+			}
+			for _, k := range parseKeys(input) {
+				if proj, act, closed := p.handle(k); closed {
+					return proj, act, nil
+				}
+			}
+		case prev := <-p.loaded:
+			p.previews[prev.name] = prev.lines
+			delete(p.loading, prev.name)
+		case <-ticker.C:
+			redraw = p.resize(conn)
+		}
+	}
+}
+
+// readKeys sends what is read from the terminal to keys until reading fails
+// or done is closed.
+func readKeys(tty *os.File, keys chan<- []byte, done <-chan struct{}) {
+	defer close(keys)
+
+	buf := make([]byte, 256)
+	for {
+		n, err := tty.Read(buf)
+		if err != nil {
+			return
+		}
+		select {
+		case keys <- append([]byte(nil), buf[:n]...):
+		case <-done:
+			return
+		}
+	}
+}
+
+// parseKeys splits terminal input into key presses. Unknown escape sequences
+// are dropped.
+func parseKeys(b []byte) []key {
+	var keys []key
+	for len(b) > 0 {
+		c := b[0]
+		switch {
+		case c == 0x1b && len(b) > 2 && (b[1] == '[' || b[1] == 'O'):
+			// CSI or SS3 sequence: parameters up to a final byte in 0x40-0x7e.
+			end := 2
+			for end < len(b) && (b[end] < 0x40 || b[end] > 0x7e) {
+				end++
+			}
+			if end == len(b) {
+				return keys
+			}
+			switch string(b[2 : end+1]) {
+			case "A":
+				keys = append(keys, key{kind: keyUp})
+			case "B":
+				keys = append(keys, key{kind: keyDown})
+			case "5~":
+				keys = append(keys, key{kind: keyPageUp})
+			case "6~":
+				keys = append(keys, key{kind: keyPageDown})
+			}
+			b = b[end+1:]
+			continue
+		case c == 0x1b:
+			keys = append(keys, key{kind: keyEsc})
+		case c == '\r':
+			keys = append(keys, key{kind: keyEnter})
+		case c == 0x7f || c == 0x08:
+			keys = append(keys, key{kind: keyBackspace})
+		case c < 0x20:
+			keys = append(keys, key{kind: keyCtrl, r: rune(c) + 'a' - 1})
+		default:
+			r, size := utf8.DecodeRune(b)
+			if unicode.IsPrint(r) {
+				keys = append(keys, key{kind: keyRune, r: r})
+			}
+			b = b[size:]
+			continue
+		}
+		b = b[1:]
+	}
+	return keys
+}
+
+// handle applies a key press. It returns closed when the picker must close,
+// along with the chosen project, which is nil if the user cancelled.
+func (p *picker) handle(k key) (proj *project.Project, act action, closed bool) {
+	switch k.kind {
+	case keyEsc:
+		return nil, actionDefault, true
+	case keyEnter:
+		return p.choose(actionDefault)
+	case keyUp:
+		p.move(-1)
+	case keyDown:
+		p.move(1)
+	case keyPageUp:
+		p.move(-p.listHeight())
+	case keyPageDown:
+		p.move(p.listHeight())
+	case keyBackspace:
+		if len(p.query) > 0 {
+			p.query = p.query[:len(p.query)-1]
+			p.filter()
+		}
+	case keyRune:
+		p.query = append(p.query, k.r)
+		p.filter()
+	case keyCtrl:
+		if act, ok := ctrlActions[k.r]; ok {
+			return p.choose(act)
+		}
+		switch k.r {
+		case 'c', 'd', 'g', 'q':
+			return nil, actionDefault, true
+		case 'p', 'k':
+			p.move(-1)
+		case 'n', 'j':
+			p.move(1)
+		case 'u':
+			p.query = p.query[:0]
+			p.filter()
+		case 'w':
+			query := strings.TrimRight(string(p.query), " ")
+			query = query[:strings.LastIndex(query, " ")+1]
+			p.query = []rune(query)
+			p.filter()
+		}
+	}
+	return nil, actionDefault, false
+}
+
+// choose closes the picker on the selected project, if any matches.
+func (p *picker) choose(act action) (*project.Project, action, bool) {
+	if len(p.matches) == 0 {
+		return nil, actionDefault, false
+	}
+	return &p.matches[p.selected], act, true
+}
+
+// filter recomputes the matches for the current query and selects the best.
+func (p *picker) filter() {
+	p.matches = project.Search(p.all, string(p.query))
+	p.selected = 0
+	p.offset = 0
+}
+
+// move moves the selection by delta, keeping it visible.
+func (p *picker) move(delta int) {
+	if len(p.matches) == 0 {
+		return
+	}
+	p.selected = min(max(p.selected+delta, 0), len(p.matches)-1)
+
+	rows := p.listHeight()
+	if p.selected < p.offset {
+		p.offset = p.selected
+	}
+	if p.selected >= p.offset+rows {
+		p.offset = p.selected - rows + 1
+	}
+}
+
+// resize reads the terminal size and reports whether it changed.
+func (p *picker) resize(conn syscall.RawConn) bool {
+	width, height := p.width, p.height
+	conn.Control(func(fd uintptr) {
+		if w, h, err := term.GetSize(int(fd)); err == nil {
+			width, height = w, h
+		}
+	})
+	if width == 0 || height == 0 {
+		width, height = 80, 24
+	}
+
+	changed := width != p.width || height != p.height
+	p.width, p.height = width, height
+	p.move(0)
+	return changed
+}
+
+// loadPreview starts loading the preview of the selected project in the
+// background unless it is cached or already loading.
+func (p *picker) loadPreview() {
+	if len(p.matches) == 0 {
+		return
+	}
+	proj := p.matches[p.selected]
+	if _, ok := p.previews[proj.Name]; ok || p.loading[proj.Name] {
+		return
+	}
+
+	p.loading[proj.Name] = true
+	go func() {
+		select {
+		case p.loaded <- loadPreview(proj):
+		case <-p.done:
+		}
+	}()
+}
+
+// listHeight is the number of rows available to the project list.
+func (p *picker) listHeight() int {
+	return max(p.height-2, 1)
+}
+
+// draw renders the whole screen: the query line, the list of matches with
+// the preview of the selected one next to it, and the key bindings.
+func (p *picker) draw() {
+	var b strings.Builder
+	b.WriteString("\x1b[H")
+
+	count := fmt.Sprintf("%d/%d", len(p.matches), len(p.all))
+	prompt := fit("> "+string(p.query), p.width-len(count)-2)
+	b.WriteString(prompt + "\x1b[7m \x1b[0m")
+	b.WriteString(strings.Repeat(" ", max(p.width-utf8.RuneCountInString(prompt)-len(count)-1, 0)))
+	b.WriteString("\x1b[2m" + count + "\x1b[0m\x1b[K\r\n")
+
+	// The preview goes to the right of the list when there is room for it.
+	listWidth := p.width
+	previewWidth := 0
+	if p.width >= 70 {
+		listWidth = min(max(p.width*2/5, 24), 50)
+		previewWidth = p.width - listWidth - 3
+	}
+
+	var previewLines []string
+	if previewWidth > 0 && len(p.matches) > 0 {
+		previewLines = p.previews[p.matches[p.selected].Name]
+		if previewLines == nil {
+			previewLines = []string{"Loading..."}
+		}
+	}
+
+	for row := 0; row < p.listHeight(); row++ {
+		b.WriteString(p.listRow(p.offset+row, listWidth))
+		if previewWidth > 0 {
+			b.WriteString(" \x1b[2m│\x1b[0m ")
+			if row < len(previewLines) {
+				line := previewLines[row]
+				if row == 0 {
+					line = "\x1b[1m" + fit(line, previewWidth) + "\x1b[0m"
+				} else {
+					line = fit(line, previewWidth)
+				}
+				b.WriteString(line)
+			}
+		}
+		b.WriteString("\x1b[K\r\n")
+	}
+
+	b.WriteString("\x1b[2m" + fit(pickerHelp, p.width) + "\x1b[0m\x1b[K")
+	fmt.Fprint(p.tty, b.String())
+}
+
+// listRow renders the list row for the match at index i, padded to width.
+func (p *picker) listRow(i, width int) string {
+	if i >= len(p.matches) {
+		return strings.Repeat(" ", width)
+	}
+
+	proj := p.matches[i]
+	label := "  " + proj.Name
+	if proj.Missing {
+		label += " (missing)"
+	}
+	label = fit(label, width)
+	label += strings.Repeat(" ", width-utf8.RuneCountInString(label))
+
+	if i == p.selected {
+		return "\x1b[7m>" + label[1:] + "\x1b[0m"
+	}
+	if proj.Missing {
+		return "\x1b[2m" + label + "\x1b[0m"
+	}
+	return label
+}
+
+// fit cuts s to at most width characters, replacing tabs and dropping other
+// control characters that would break the layout.
+func fit(s string, width int) string {
+	s = strings.ReplaceAll(s, "\t", "    ")
+	s = strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, s)
+
+	if width <= 0 {
+		return ""
+	}
+	if utf8.RuneCountInString(s) > width {
+		runes := []rune(s)
+		s = string(runes[:width-1]) + "…"
+	}
+	return s
+}
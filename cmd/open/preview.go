@@ -0,0 +1,121 @@
+package open
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/okalexiiis/dwrk/internal/git"
+	"github.com/okalexiiis/dwrk/internal/project"
+	"github.com/okalexiiis/dwrk/pkg/utils"
+)
+
+// readmeFiles are the names looked up for the README shown in the preview.
+var readmeFiles = []string{"README.md", "README", "README.txt", "README.rst", "readme.md"}
+
+// readmeLines is the number of README lines read for the preview.
+const readmeLines = 40
+
+// preview describes a project for the picker's preview pane.
+type preview struct {
+	name  string
+	lines []string
+}
+
+// loadPreview gathers the details shown for a project: path, Git branch and
+// state, modification times, registry metadata and the head of its README.
+func loadPreview(proj project.Project) preview {
+	lines := []string{proj.Name, ""}
+	field := func(label, value string) {
+		lines = append(lines, fmt.Sprintf("%-10s %s", label, value))
+	}
+
+	field("Path", proj.Path)
+
+	if proj.Missing {
+		field("Status", "missing, the directory no longer exists")
+		return preview{name: proj.Name, lines: lines}
+	}
+
+	if proj.IsGit {
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+
+		status, err := git.GetStatus(ctx, proj.Path)
+		if err != nil {
+			field("Branch", fmt.Sprintf("error: %v", err))
+		} else {
+			field("Branch", branchLabel(status))
+			field("Changes", changesLabel(status.Dirty))
+			field("Commit", utils.Age(status.LastCommit))
+		}
+	} else {
+		field("Branch", "not a Git repository")
+	}
+
+	if !proj.LastMod.IsZero() {
+		field("Modified", fmt.Sprintf("%s (%s)", proj.LastMod.Format("2006-01-02 15:04"), utils.Age(proj.LastMod)))
+	}
+	if proj.WorktreeOf != nil {
+		field("Worktree", fmt.Sprintf("of %s", proj.WorktreeOf.Project))
+	}
+	if proj.Template != "" {
+		field("Template", proj.Template)
+	}
+	if len(proj.Tags) > 0 {
+		field("Tags", strings.Join(proj.Tags, ", "))
+	}
+
+	if name, head := readReadme(proj.Path); name != "" {
+		lines = append(lines, "", "── "+name+" ──")
+		lines = append(lines, head...)
+	}
+
+	return preview{name: proj.Name, lines: lines}
+}
+
+// branchLabel returns the branch with its position relative to the upstream.
+func branchLabel(s *git.Status) string {
+	label := s.Branch
+	if s.Detached {
+		label = "(detached)"
+	}
+	if s.Ahead > 0 {
+		label += fmt.Sprintf(" ↑%d", s.Ahead)
+	}
+	if s.Behind > 0 {
+		label += fmt.Sprintf(" ↓%d", s.Behind)
+	}
+	return label
+}
+
+// changesLabel describes the number of uncommitted paths.
+func changesLabel(dirty int) string {
+	if dirty == 0 {
+		return "clean"
+	}
+	return fmt.Sprintf("%d uncommitted", dirty)
+}
+
+// readReadme returns the name and first lines of the README in dir, if any.
+func readReadme(dir string) (string, []string) {
+	for _, name := range readmeFiles {
+		f, err := os.Open(filepath.Join(dir, name))
+		if err != nil {
+			continue
+		}
+		defer f.Close()
+
+		var lines []string
+		scanner := bufio.NewScanner(f)
+		for len(lines) < readmeLines && scanner.Scan() {
+			lines = append(lines, scanner.Text())
+		}
+		return name, lines
+	}
+	return "", nil
+}
@@ -0,0 +1,212 @@
+package status
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/okalexiiis/dwrk/internal/config"
+	"github.com/okalexiiis/dwrk/internal/git"
+	"github.com/okalexiiis/dwrk/internal/project"
+	"github.com/okalexiiis/dwrk/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	onlyDirty  bool
+	onlyBehind bool
+	asJSON     bool
+	filterName string
+	jobs       int
+)
+
+// StatusCmd defines the `dwrk status` command.
+//
+// It shows the Git state of every project that is a repository: current
+// branch, commits ahead of and behind the upstream, uncommitted changes,
+// stashes and the age of the last commit.
+var StatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the Git status of all projects",
+	Long: `Show the Git status of every project that is a Git repository.
+
+Columns:
+  BRANCH        current branch, or the commit when HEAD is detached
+  SYNC          commits ahead (↑) and behind (↓) the upstream; "=" when in
+                sync, "-" without an upstream
+  DIRTY         changed and untracked files
+  STASH         stash entries
+  LAST COMMIT   age of the last commit
+
+Examples:
+  dwrk status
+  dwrk status --dirty
+  dwrk status --behind --filter api
+  dwrk status --json`,
+	Args: cobra.NoArgs,
+	Run:  runStatus,
+}
+
+// repoStatus is the status of one project, as printed by --json.
+type repoStatus struct {
+	Name       string    `json:"name"`
+	Path       string    `json:"path"`
+	Branch     string    `json:"branch,omitempty"`
+	Detached   bool      `json:"detached,omitempty"`
+	Commit     string    `json:"commit,omitempty"`
+	Upstream   string    `json:"upstream,omitempty"`
+	Ahead      int       `json:"ahead"`
+	Behind     int       `json:"behind"`
+	Dirty      int       `json:"dirty"`
+	Stashes    int       `json:"stashes"`
+	LastCommit time.Time `json:"last_commit,omitzero"`
+	Error      string    `json:"error,omitempty"`
+}
+
+func init() {
+	StatusCmd.Flags().BoolVar(&onlyDirty, "dirty", false, "Only show projects with uncommitted changes")
+	StatusCmd.Flags().BoolVar(&onlyBehind, "behind", false, "Only show projects behind their upstream")
+	StatusCmd.Flags().BoolVar(&asJSON, "json", false, "Print the status as JSON")
+	StatusCmd.Flags().StringVarP(&filterName, "filter", "f", "", "Filter projects by name")
+	StatusCmd.Flags().IntVarP(&jobs, "jobs", "j", 8, "Number of repositories inspected in parallel")
+}
+
+func runStatus(cmd *cobra.Command, args []string) {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	manager := project.NewManager(cfg)
+
+	projects, err := manager.List(project.ListOptions{Filter: filterName})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error listing projects: %v\n", err)
+		os.Exit(1)
+	}
+
+	var repos []project.Project
+	for _, proj := range projects {
+		if proj.IsGit && !proj.Missing {
+			repos = append(repos, proj)
+		}
+	}
+
+	statuses := make([]repoStatus, len(repos))
+	utils.Parallel(len(repos), jobs, func(i int) {
+		statuses[i] = inspect(cmd, repos[i])
+	})
+
+	// Repositories whose status could not be read are always shown, since
+	// the filters cannot tell whether they match.
+	var shown []repoStatus
+	for _, s := range statuses {
+		if s.Error != "" {
+			shown = append(shown, s)
+			continue
+		}
+		if onlyDirty && s.Dirty == 0 {
+			continue
+		}
+		if onlyBehind && s.Behind == 0 {
+			continue
+		}
+		shown = append(shown, s)
+	}
+
+	if asJSON {
+		if shown == nil {
+			shown = []repoStatus{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		enc.Encode(shown)
+		return
+	}
+
+	if len(shown) == 0 {
+		fmt.Println("No matching Git repositories found")
+		return
+	}
+
+	printTable(shown)
+}
+
+// inspect reads the Git status of a project.
+func inspect(cmd *cobra.Command, proj project.Project) repoStatus {
+	s := repoStatus{Name: proj.Name, Path: proj.Path}
+
+	st, err := git.GetStatus(cmd.Context(), proj.Path)
+	if err != nil {
+		s.Error = err.Error()
+		return s
+	}
+
+	s.Branch = st.Branch
+	s.Detached = st.Detached
+	s.Commit = st.Commit
+	s.Upstream = st.Upstream
+	s.Ahead = st.Ahead
+	s.Behind = st.Behind
+	s.Dirty = st.Dirty
+	s.Stashes = st.Stashes
+	s.LastCommit = st.LastCommit
+	return s
+}
+
+// printTable prints the statuses as an aligned table.
+func printTable(statuses []repoStatus) {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROJECT\tBRANCH\tSYNC\tDIRTY\tSTASH\tLAST COMMIT")
+
+	for _, s := range statuses {
+		if s.Error != "" {
+			fmt.Fprintf(w, "%s\terror: %s\t\t\t\t\n", s.Name, s.Error)
+			continue
+		}
+
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n",
+			s.Name, branchLabel(s), syncLabel(s), countLabel(s.Dirty), countLabel(s.Stashes), utils.Age(s.LastCommit))
+	}
+
+	w.Flush()
+}
+
+// branchLabel returns the branch name, or the short commit on a detached HEAD.
+func branchLabel(s repoStatus) string {
+	if !s.Detached {
+		return s.Branch
+	}
+	commit := s.Commit
+	if len(commit) > 7 {
+		commit = commit[:7]
+	}
+	return fmt.Sprintf("(detached %s)", commit)
+}
+
+// syncLabel describes the position of the branch relative to its upstream.
+func syncLabel(s repoStatus) string {
+	switch {
+	case s.Upstream == "":
+		return "-"
+	case s.Ahead == 0 && s.Behind == 0:
+		return "="
+	case s.Behind == 0:
+		return fmt.Sprintf("↑%d", s.Ahead)
+	case s.Ahead == 0:
+		return fmt.Sprintf("↓%d", s.Behind)
+	default:
+		return fmt.Sprintf("↑%d ↓%d", s.Ahead, s.Behind)
+	}
+}
+
+// countLabel prints zero as a dash so non-zero counts stand out.
+func countLabel(n int) string {
+	if n == 0 {
+		return "-"
+	}
+	return fmt.Sprint(n)
+}
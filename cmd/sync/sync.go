@@ -0,0 +1,206 @@
+package sync
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/okalexiiis/dwrk/internal/config"
+	"github.com/okalexiiis/dwrk/internal/git"
+	"github.com/okalexiiis/dwrk/internal/project"
+	"github.com/okalexiiis/dwrk/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	all     bool
+	jobs    int
+	timeout time.Duration
+)
+
+// Outcomes of syncing a repository.
+const (
+	resultUpdated  = "updated"
+	resultUpToDate = "up to date"
+	resultSkipped  = "skipped"
+	resultFailed   = "failed"
+)
+
+// SyncCmd defines the `dwrk sync` command.
+//
+// It fetches every selected repository and fast-forwards the current branch
+// to its upstream when that is safe to do.
+var SyncCmd = &cobra.Command{
+	Use:   "sync [names...]",
+	Short: "Fetch and fast-forward projects",
+	Long: `Fetch the given projects (or every Git project with --all) and
+fast-forward their current branch to its upstream.
+
+Repositories are fetched with --prune. A repository is only updated when it
+is on a branch with an upstream that still exists, has no uncommitted changes
+to tracked files and has not diverged from the upstream; otherwise it is
+skipped with the reason. The command exits with a non-zero status if any
+repository fails.
+
+Examples:
+  dwrk sync api web
+  dwrk sync --all
+  dwrk sync --all --jobs 8 --timeout 30s`,
+	Run: runSync,
+}
+
+// syncResult is the outcome of syncing one project.
+type syncResult struct {
+	name   string
+	result string
+	detail string
+}
+
+func init() {
+	SyncCmd.Flags().BoolVarP(&all, "all", "a", false, "Sync every Git project")
+	SyncCmd.Flags().IntVarP(&jobs, "jobs", "j", 4, "Number of repositories synced in parallel")
+	SyncCmd.Flags().DurationVar(&timeout, "timeout", 2*time.Minute, "Time limit for each repository")
+}
+
+func runSync(cmd *cobra.Command, args []string) {
+	if all == (len(args) > 0) {
+		fmt.Fprintln(os.Stderr, "Error: specify project names or --all")
+		os.Exit(1)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+
+	repos, err := selectProjects(project.NewManager(cfg), args)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+	if len(repos) == 0 {
+		fmt.Println("No Git projects to sync")
+		return
+	}
+
+	fmt.Printf("Syncing %d project(s)...\n\n", len(repos))
+
+	results := make([]syncResult, len(repos))
+	utils.Parallel(len(repos), jobs, func(i int) {
+		ctx, cancel := context.WithTimeout(cmd.Context(), timeout)
+		defer cancel()
+
+		results[i] = syncRepo(ctx, repos[i])
+	})
+
+	if failed := printResults(results); failed > 0 {
+		os.Exit(1)
+	}
+}
+
+// selectProjects returns the named projects, or every Git project when no
+// names are given.
+func selectProjects(manager *project.Manager, names []string) ([]project.Project, error) {
+	if len(names) == 0 {
+		projects, err := manager.List(project.ListOptions{})
+		if err != nil {
+			return nil, err
+		}
+
+		var repos []project.Project
+		for _, proj := range projects {
+			if proj.IsGit && !proj.Missing {
+				repos = append(repos, proj)
+			}
+		}
+		return repos, nil
+	}
+
+	var repos []project.Project
+	for _, name := range names {
+		proj, err := manager.GetExact(name)
+		if err != nil {
+			return nil, err
+		}
+		if proj.Missing {
+			return nil, fmt.Errorf("project '%s' is registered at %s but the directory no longer exists", proj.Name, proj.Path)
+		}
+		if !proj.IsGit {
+			return nil, fmt.Errorf("project '%s' is not a Git repository", proj.Name)
+		}
+		repos = append(repos, *proj)
+	}
+	return repos, nil
+}
+
+// syncRepo fetches a repository and fast-forwards it if it is safe to.
+func syncRepo(ctx context.Context, proj project.Project) syncResult {
+	r := syncResult{name: proj.Name}
+
+	if err := git.Fetch(ctx, proj.Path); err != nil {
+		r.result, r.detail = resultFailed, "fetch: "+err.Error()
+		return r
+	}
+
+	st, err := git.GetStatus(ctx, proj.Path)
+	if err != nil {
+		r.result, r.detail = resultFailed, err.Error()
+		return r
+	}
+
+	switch {
+	case st.Detached:
+		r.result, r.detail = resultSkipped, "detached HEAD"
+		return r
+	case st.Upstream == "":
+		r.result, r.detail = resultSkipped, fmt.Sprintf("branch '%s' has no upstream", st.Branch)
+		return r
+	case st.Gone:
+		r.result, r.detail = resultSkipped, fmt.Sprintf("upstream %s is gone", st.Upstream)
+		return r
+	case st.Behind == 0:
+		r.result = resultUpToDate
+		if st.Ahead > 0 {
+			r.detail = fmt.Sprintf("%d local commit(s) not pushed", st.Ahead)
+		}
+		return r
+	case st.Dirty > st.Untracked:
+		// Untracked files do not block a fast-forward; git refuses it by
+		// itself if one would be overwritten.
+		r.result, r.detail = resultSkipped, fmt.Sprintf("%d uncommitted change(s)", st.Dirty-st.Untracked)
+		return r
+	case st.Ahead > 0:
+		r.result, r.detail = resultSkipped, fmt.Sprintf("diverged from %s (↑%d ↓%d)", st.Upstream, st.Ahead, st.Behind)
+		return r
+	}
+
+	if err := git.FastForward(ctx, proj.Path); err != nil {
+		r.result, r.detail = resultFailed, "merge: "+err.Error()
+		return r
+	}
+
+	r.result, r.detail = resultUpdated, fmt.Sprintf("fast-forwarded %d commit(s) from %s", st.Behind, st.Upstream)
+	return r
+}
+
+// printResults prints a table of the results followed by a summary and
+// returns the number of failed repositories.
+func printResults(results []syncResult) int {
+	counts := map[string]int{}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROJECT\tRESULT\tDETAIL")
+	for _, r := range results {
+		counts[r.result]++
+		fmt.Fprintf(w, "%s\t%s\t%s\n", r.name, r.result, r.detail)
+	}
+	w.Flush()
+
+	fmt.Printf("\n%d updated, %d up to date, %d skipped, %d failed\n",
+		counts[resultUpdated], counts[resultUpToDate], counts[resultSkipped], counts[resultFailed])
+
+	return counts[resultFailed]
+}
@@ -0,0 +1,327 @@
+package template
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/okalexiiis/dwrk/internal/config"
+	"github.com/okalexiiis/dwrk/internal/project"
+	"github.com/okalexiiis/dwrk/internal/templates"
+	"github.com/okalexiiis/dwrk/pkg/utils"
+	"github.com/spf13/cobra"
+)
+
+// flags
+var (
+	fromFlag        string
+	removeForceFlag bool
+	refFlag         string
+	varsFlag        []string
+	updateForceFlag bool
+)
+
+// TemplateCmd groups the commands that manage project templates.
+var TemplateCmd = &cobra.Command{
+	Use:   "template",
+	Short: "Manage project templates",
+	Long: `Manage the project templates stored in templates_dir.
+
+Subcommands:
+  list     List available templates
+  show     Show the variables and files of a template
+  add      Add a template from a directory or a Git repository
+  remove   Remove a template
+  update   Merge a newer template version into a project`,
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List available templates",
+	Args:  cobra.NoArgs,
+	Run:   runList,
+}
+
+var showCmd = &cobra.Command{
+	Use:   "show <name|repo//subdir@ref>",
+	Short: "Show the variables and files of a template",
+	Long: `Show the variables, hooks and files of a template.
+
+Git-hosted templates are fetched into the template cache first.`,
+	Args: cobra.ExactArgs(1),
+	Run:  runShow,
+}
+
+var addCmd = &cobra.Command{
+	Use:   "add <name> --from <dir|git-url>",
+	Short: "Add a template from a directory or a Git repository",
+	Long: `Add a template to templates_dir.
+
+A directory is copied as a snapshot; anything else is cloned with git, so the
+template can later be refreshed from its remote.
+
+Examples:
+  dwrk template add go-service --from ~/work/go-service-skeleton
+  dwrk template add node-api --from git@github.com:our-org/node-api-template.git`,
+	Args: cobra.ExactArgs(1),
+	Run:  runAdd,
+}
+
+var removeCmd = &cobra.Command{
+	Use:   "remove <name>",
+	Short: "Remove a template",
+	Args:  cobra.ExactArgs(1),
+	Run:   runRemove,
+}
+
+var updateCmd = &cobra.Command{
+	Use:   "update <project>",
+	Short: "Merge a newer template version into a project",
+	Long: `Re-render the template a project was generated from at a newer version and
+merge the changes into the project.
+
+The recorded template version is rendered again with the recorded variables
+and used as the common ancestor of a three-way merge between the project and
+the new render. Files changed on both sides are merged with git; overlapping
+changes are left with conflict markers to resolve by hand.
+
+Only projects created from Git-hosted or Git-tracked templates can be updated.
+
+Examples:
+  dwrk template update api-server
+  dwrk template update api-server --ref v1.5`,
+	Args: cobra.ExactArgs(1),
+	Run:  runUpdate,
+}
+
+func init() {
+	addCmd.Flags().StringVar(&fromFlag, "from", "", "Directory or Git URL to create the template from")
+	addCmd.MarkFlagRequired("from")
+
+	removeCmd.Flags().BoolVarP(&removeForceFlag, "force", "f", false, "Do not ask for confirmation")
+
+	updateCmd.Flags().StringVar(&refFlag, "ref", "", "Template branch, tag or commit to update to")
+	updateCmd.Flags().StringArrayVar(&varsFlag, "var", nil, "Override a template variable as key=value (repeatable)")
+	updateCmd.Flags().BoolVarP(&updateForceFlag, "force", "f", false, "Update even if the project has uncommitted changes")
+
+	TemplateCmd.AddCommand(listCmd)
+	TemplateCmd.AddCommand(showCmd)
+	TemplateCmd.AddCommand(addCmd)
+	TemplateCmd.AddCommand(removeCmd)
+	TemplateCmd.AddCommand(updateCmd)
+}
+
+// loadConfig loads the configuration, exiting on failure.
+func loadConfig() *config.Config {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	return cfg
+}
+
+// templatesDir loads the configuration and returns the expanded templates directory.
+func templatesDir() string {
+	return utils.ExpandPath(loadConfig().TemplatesDir)
+}
+
+func runList(cmd *cobra.Command, args []string) {
+	dir := templatesDir()
+
+	list, err := templates.List(dir)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if len(list) == 0 {
+		fmt.Printf("No templates found in: %s\n", dir)
+		fmt.Println("\nTip: Add one using:")
+		fmt.Println("   dwrk template add my-template --from ./skeleton")
+		return
+	}
+
+	fmt.Printf("Templates in %s:\n\n", dir)
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "  NAME\tDESCRIPTION\tSOURCE")
+	for _, tmpl := range list {
+		fmt.Fprintf(w, "  %s\t%s\t%s\n", tmpl.Name, tmpl.Description(), tmpl.Source)
+	}
+	w.Flush()
+
+	fmt.Printf("\nTotal: %d template(s)\n", len(list))
+}
+
+func runShow(cmd *cobra.Command, args []string) {
+	cfg := loadConfig()
+
+	tmpl, err := templates.NewResolver(utils.ExpandPath(cfg.TemplatesDir), cfg.UseSSH).Resolve(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Template: %s\n", tmpl.Name)
+	if desc := tmpl.Description(); desc != "" {
+		fmt.Printf("Description: %s\n", desc)
+	}
+	fmt.Printf("Location: %s\n", tmpl.Path)
+	fmt.Printf("Source: %s\n", tmpl.Template.Source)
+	if tmpl.Commit != "" {
+		fmt.Printf("Commit: %s\n", tmpl.Commit)
+	}
+
+	if len(tmpl.Manifest.Variables) > 0 {
+		fmt.Println("\nVariables:")
+
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "  NAME\tTYPE\tDEFAULT\tREQUIRED\tDESCRIPTION")
+		for _, v := range tmpl.Manifest.Variables {
+			typ := v.Type
+			if v.Type == templates.TypeChoice {
+				typ = fmt.Sprintf("choice(%s)", strings.Join(v.Choices, "|"))
+			}
+			required := ""
+			if v.Required {
+				required = "yes"
+			}
+			fmt.Fprintf(w, "  %s\t%s\t%s\t%s\t%s\n", v.Name, typ, v.Default, required, v.Description)
+		}
+		w.Flush()
+	}
+
+	if len(tmpl.Manifest.PostCreate) > 0 {
+		fmt.Println("\nPost-create hooks:")
+		for _, hook := range tmpl.Manifest.PostCreate {
+			fmt.Printf("  %s\n", hook)
+		}
+	}
+
+	if len(tmpl.Manifest.Verbatim) > 0 {
+		fmt.Printf("\nCopied verbatim: %s\n", strings.Join(tmpl.Manifest.Verbatim, ", "))
+	}
+
+	files, err := tmpl.Files()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading template files: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("\nFiles:")
+	for _, file := range files {
+		depth := strings.Count(strings.TrimSuffix(file, string(filepath.Separator)), string(filepath.Separator))
+		name := filepath.Base(file)
+		if strings.HasSuffix(file, string(filepath.Separator)) {
+			name += "/"
+		}
+		fmt.Printf("  %s%s\n", strings.Repeat("  ", depth), name)
+	}
+}
+
+func runAdd(cmd *cobra.Command, args []string) {
+	tmpl, err := templates.Add(templatesDir(), args[0], fromFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Template added: %s\n", tmpl.Name)
+	fmt.Printf("Location: %s\n", tmpl.Path)
+
+	fmt.Println("\nTo create a project from it:")
+	fmt.Printf("  dwrk new my-project -t %s\n", tmpl.Name)
+}
+
+func runRemove(cmd *cobra.Command, args []string) {
+	name := args[0]
+	dir := templatesDir()
+
+	tmpl, err := templates.Get(dir, name)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	if !removeForceFlag {
+		fmt.Printf("Are you sure you want to remove the template '%s' (%s)? [y/N]: ", name, tmpl.Path)
+
+		var response string
+		fmt.Scanln(&response)
+
+		if response != "y" && response != "Y" && response != "yes" {
+			fmt.Println("Operation cancelled")
+			return
+		}
+	}
+
+	if err := templates.Remove(dir, name); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Template removed: %s\n", name)
+}
+
+func runUpdate(cmd *cobra.Command, args []string) {
+	vars, err := utils.ParseVars(varsFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	manager := project.NewManager(loadConfig())
+
+	update, err := manager.UpdateTemplate(args[0], project.UpdateTemplateOptions{
+		Ref:         refFlag,
+		Vars:        vars,
+		Interactive: utils.IsTerminal(os.Stdin),
+		Force:       updateForceFlag,
+	})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Template updated: %s -> %s\n", shortCommit(update.FromCommit), shortCommit(update.ToCommit))
+
+	if !update.Changed() && len(update.Kept)+len(update.Missing) == 0 {
+		fmt.Println("\nThe project is already up to date")
+		return
+	}
+
+	printFiles("Updated", update.Updated)
+	printFiles("Added", update.Added)
+	printFiles("Deleted", update.Deleted)
+	printFiles("Kept (removed upstream, changed locally)", update.Kept)
+	printFiles("Not restored (changed upstream, deleted locally)", update.Missing)
+	printFiles("Conflicts", update.Conflicts)
+
+	if len(update.Conflicts) > 0 {
+		fmt.Printf("\n%d file(s) have conflicts. Resolve the conflict markers (or the %s copies of binary files) before committing.\n",
+			len(update.Conflicts), templates.ConflictSuffix)
+		os.Exit(1)
+	}
+}
+
+// printFiles prints a titled list of files, if any.
+func printFiles(title string, files []string) {
+	if len(files) == 0 {
+		return
+	}
+	fmt.Printf("\n%s:\n", title)
+	for _, file := range files {
+		fmt.Printf("  %s\n", file)
+	}
+}
+
+// shortCommit abbreviates a commit hash for display.
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
@@ -0,0 +1,256 @@
+package worktree
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/okalexiiis/dwrk/internal/config"
+	"github.com/okalexiiis/dwrk/internal/project"
+	"github.com/spf13/cobra"
+)
+
+// flags
+var (
+	fromFlag  string
+	forceFlag bool
+	allFlag   bool
+)
+
+// WorktreeCmd groups the commands that manage Git worktrees of projects.
+var WorktreeCmd = &cobra.Command{
+	Use:   "worktree",
+	Short: "Manage Git worktrees of projects",
+	Long: `Manage Git worktrees of projects.
+
+Worktrees are created next to the project, in <project>.worktrees/<branch>,
+and registered as <project>@<branch> so they can be used like any other
+project (e.g. dwrk open api@fix/login).
+
+Subcommands:
+  add      Check out a branch in a new worktree
+  list     List the worktrees of a project, or of every project
+  remove   Remove a worktree and unregister it
+  prune    Clean up worktrees whose directory was deleted`,
+}
+
+var addCmd = &cobra.Command{
+	Use:   "add <project> <branch>",
+	Short: "Check out a branch in a new worktree",
+	Long: `Check out a branch of a project in a new worktree and register it as
+<project>@<branch>.
+
+An existing local branch is checked out as is, and a branch that only exists
+on a remote is created tracking it. Any other name creates a new branch
+from the project's HEAD, or from --from.
+
+Examples:
+  dwrk worktree add api fix/login
+  dwrk worktree add api hotfix-1.4 --from v1.4.0
+  dwrk open api@fix/login`,
+	Args: cobra.ExactArgs(2),
+	Run:  runAdd,
+}
+
+var listCmd = &cobra.Command{
+	Use:   "list [project]",
+	Short: "List the worktrees of a project, or of every project",
+	Args:  cobra.MaximumNArgs(1),
+	Run:   runList,
+}
+
+var removeCmd = &cobra.Command{
+	Use:   "remove <project> <branch|path|commit> | <project@branch>",
+	Short: "Remove a worktree and unregister it",
+	Long: `Remove the worktree of a project that has the given branch checked out,
+unregister it and prune stale worktree metadata. The branch is kept.
+
+Worktrees on a detached HEAD, shown by 'dwrk worktree list' as
+"(detached <commit>)", are given by their path or commit instead.
+
+A worktree with uncommitted changes is only removed with --force.
+
+Examples:
+  dwrk worktree remove api fix/login
+  dwrk worktree remove api@fix/login --force
+  dwrk worktree remove api 1a2b3c4`,
+	Args: cobra.RangeArgs(1, 2),
+	Run:  runRemove,
+}
+
+var pruneCmd = &cobra.Command{
+	Use:   "prune [project|--all]",
+	Short: "Clean up worktrees whose directory was deleted",
+	Long: `Run 'git worktree prune' in a project (or every Git project with --all)
+and unregister its worktrees whose directory no longer exists.`,
+	Args: cobra.MaximumNArgs(1),
+	Run:  runPrune,
+}
+
+func init() {
+	addCmd.Flags().StringVar(&fromFlag, "from", "", "Branch, tag or commit to start a new branch from (default: HEAD)")
+
+	removeCmd.Flags().BoolVarP(&forceFlag, "force", "f", false, "Remove the worktree even if it has uncommitted changes")
+
+	pruneCmd.Flags().BoolVarP(&allFlag, "all", "a", false, "Prune every Git project")
+
+	WorktreeCmd.AddCommand(addCmd)
+	WorktreeCmd.AddCommand(listCmd)
+	WorktreeCmd.AddCommand(removeCmd)
+	WorktreeCmd.AddCommand(pruneCmd)
+}
+
+// newManager loads the configuration and returns a project manager, exiting
+// on failure.
+func newManager() *project.Manager {
+	cfg, err := config.Load()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error loading configuration: %v\n", err)
+		os.Exit(1)
+	}
+	return project.NewManager(cfg)
+}
+
+func runAdd(cmd *cobra.Command, args []string) {
+	wt, err := newManager().AddWorktree(cmd.Context(), args[0], args[1], fromFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Worktree created: %s\n", wt.Name)
+	fmt.Printf("Location: %s\n", wt.Path)
+
+	fmt.Println("\nTo open it:")
+	fmt.Printf("  dwrk open %s\n", wt.Name)
+}
+
+func runList(cmd *cobra.Command, args []string) {
+	manager := newManager()
+
+	names := args
+	if len(names) == 0 {
+		projects, err := manager.List(project.ListOptions{})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error listing projects: %v\n", err)
+			os.Exit(1)
+		}
+		for _, proj := range projects {
+			if proj.IsGit && !proj.Missing && proj.WorktreeOf == nil {
+				names = append(names, proj.Name)
+			}
+		}
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "PROJECT\tBRANCH\tNAME\tPATH")
+
+	// A project that cannot be read is reported and the others still listed.
+	count, failed := 0, 0
+	for _, name := range names {
+		worktrees, err := manager.Worktrees(cmd.Context(), name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", name, err)
+			failed++
+			continue
+		}
+
+		for _, wt := range worktrees {
+			branch := wt.Branch
+			if branch == "" {
+				branch = fmt.Sprintf("(detached %.7s)", wt.Commit)
+			}
+			wtName := wt.Name
+			if wtName == "" {
+				wtName = "-"
+			}
+			path := wt.Path
+			if wt.Prunable {
+				path += " (missing, run dwrk worktree prune)"
+			}
+			fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", name, branch, wtName, path)
+			count++
+		}
+	}
+
+	if count == 0 {
+		fmt.Println("No worktrees found")
+	} else {
+		w.Flush()
+	}
+
+	if failed > 0 {
+		os.Exit(1)
+	}
+}
+
+func runRemove(cmd *cobra.Command, args []string) {
+	name, target := args[0], ""
+	if len(args) == 2 {
+		target = args[1]
+	} else {
+		var ok bool
+		name, target, ok = strings.Cut(args[0], project.WorktreeSeparator)
+		if !ok {
+			fmt.Fprintln(os.Stderr, "Error: specify a project and a branch, path or commit, or <project@branch>")
+			os.Exit(1)
+		}
+	}
+
+	wt, err := newManager().RemoveWorktree(cmd.Context(), name, target, forceFlag)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	removed := wt.Name
+	if removed == "" {
+		removed = wt.Path
+	}
+	fmt.Printf("Worktree removed: %s\n", removed)
+}
+
+func runPrune(cmd *cobra.Command, args []string) {
+	if allFlag == (len(args) > 0) {
+		fmt.Fprintln(os.Stderr, "Error: specify a project name or --all")
+		os.Exit(1)
+	}
+
+	manager := newManager()
+
+	names := args
+	if allFlag {
+		projects, err := manager.List(project.ListOptions{})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error listing projects: %v\n", err)
+			os.Exit(1)
+		}
+		for _, proj := range projects {
+			if proj.IsGit && !proj.Missing && proj.WorktreeOf == nil {
+				names = append(names, proj.Name)
+			}
+		}
+	}
+
+	// A project that fails is reported and the others still pruned.
+	total, failed := 0, 0
+	for _, name := range names {
+		pruned, err := manager.PruneWorktrees(cmd.Context(), name)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error: %s: %v\n", name, err)
+			failed++
+			continue
+		}
+		for _, wtName := range pruned {
+			fmt.Printf("Unregistered %s\n", wtName)
+		}
+		total += len(pruned)
+	}
+
+	fmt.Printf("Worktrees pruned, %d stale registration(s) removed\n", total)
+	if failed > 0 {
+		fmt.Fprintf(os.Stderr, "%d project(s) could not be pruned\n", failed)
+		os.Exit(1)
+	}
+}
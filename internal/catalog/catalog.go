@@ -0,0 +1,177 @@
+// Package catalog embeds the .gitignore templates and license texts that can
+// be added to projects.
+package catalog
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"text/template"
+)
+
+//go:embed gitignore/*.gitignore licenses/*.txt
+var files embed.FS
+
+// License is a license text available in the catalog.
+type License struct {
+	ID   string // SPDX identifier, e.g. "Apache-2.0"
+	Name string
+}
+
+// licenses lists the license texts in licenses/, stored as <ID>.txt.
+var licenses = []License{
+	{ID: "0BSD", Name: "BSD Zero Clause License"},
+	{ID: "Apache-2.0", Name: "Apache License 2.0"},
+	{ID: "BSD-2-Clause", Name: "BSD 2-Clause \"Simplified\" License"},
+	{ID: "BSD-3-Clause", Name: "BSD 3-Clause \"New\" or \"Revised\" License"},
+	{ID: "BSL-1.0", Name: "Boost Software License 1.0"},
+	{ID: "ISC", Name: "ISC License"},
+	{ID: "MIT", Name: "MIT License"},
+	{ID: "Unlicense", Name: "The Unlicense"},
+	{ID: "Zlib", Name: "zlib License"},
+}
+
+// Licenses returns every license in the catalog, sorted by identifier.
+func Licenses() []License {
+	return licenses
+}
+
+// FindLicense returns the license with the given SPDX identifier, ignoring case.
+func FindLicense(id string) (*License, error) {
+	for _, l := range licenses {
+		if strings.EqualFold(l.ID, id) {
+			return &l, nil
+		}
+	}
+
+	ids := make([]string, len(licenses))
+	for i, l := range licenses {
+		ids[i] = l.ID
+	}
+	return nil, fmt.Errorf("unknown license '%s' (available: %s)", id, strings.Join(ids, ", "))
+}
+
+// Render returns the license text with the copyright holder and year filled in.
+func (l *License) Render(holder string, year int) ([]byte, error) {
+	text, err := files.ReadFile(path.Join("licenses", l.ID+".txt"))
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := template.New(l.ID).Parse(string(text))
+	if err != nil {
+		return nil, fmt.Errorf("invalid license template '%s': %w", l.ID, err)
+	}
+
+	var buf bytes.Buffer
+	data := struct {
+		Holder string
+		Year   int
+	}{holder, year}
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("failed rendering license '%s': %w", l.ID, err)
+	}
+	return buf.Bytes(), nil
+}
+
+// Gitignores returns the names of the .gitignore templates, sorted.
+func Gitignores() []string {
+	entries, _ := fs.ReadDir(files, "gitignore")
+
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), ".gitignore"))
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Gitignore returns a .gitignore combining the named templates, each in a
+// section headed by "### <name> ###".
+func Gitignore(names ...string) ([]byte, error) {
+	content, _, err := MergeGitignore(nil, names...)
+	return content, err
+}
+
+// MergeGitignore appends the named templates to an existing .gitignore and
+// returns the result with the names of the templates that added something.
+//
+// Merging is idempotent: templates whose section is already present are
+// skipped, and patterns the file already contains are not repeated. Groups of
+// lines left without patterns are dropped along with their comments.
+func MergeGitignore(existing []byte, names ...string) ([]byte, []string, error) {
+	var buf bytes.Buffer
+	buf.Write(existing)
+
+	patterns := map[string]bool{}
+	for _, line := range strings.Split(string(existing), "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "###") {
+			// Section headers written by hand or other tools may differ in case.
+			line = strings.ToLower(line)
+		}
+		patterns[line] = true
+	}
+
+	var added []string
+	for _, name := range names {
+		name = strings.ToLower(name)
+		header := fmt.Sprintf("### %s ###", name)
+		if patterns[header] {
+			continue
+		}
+
+		content, err := gitignoreTemplate(name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		var blocks []string
+		for _, block := range strings.Split(strings.TrimSpace(string(content)), "\n\n") {
+			var lines []string
+			hasPattern := false
+			for _, line := range strings.Split(block, "\n") {
+				trimmed := strings.TrimSpace(line)
+				if trimmed != "" && !strings.HasPrefix(trimmed, "#") {
+					if patterns[trimmed] {
+						continue
+					}
+					patterns[trimmed] = true
+					hasPattern = true
+				}
+				lines = append(lines, line)
+			}
+			if hasPattern {
+				blocks = append(blocks, strings.Join(lines, "\n"))
+			}
+		}
+		if len(blocks) == 0 {
+			continue
+		}
+
+		if buf.Len() > 0 {
+			if !bytes.HasSuffix(buf.Bytes(), []byte("\n")) {
+				buf.WriteString("\n")
+			}
+			buf.WriteString("\n")
+		}
+		fmt.Fprintf(&buf, "%s\n%s\n", header, strings.Join(blocks, "\n\n"))
+		patterns[header] = true
+		added = append(added, name)
+	}
+
+	return buf.Bytes(), added, nil
+}
+
+// gitignoreTemplate returns the contents of one .gitignore template.
+func gitignoreTemplate(name string) ([]byte, error) {
+	content, err := files.ReadFile(path.Join("gitignore", strings.ToLower(name)+".gitignore"))
+	if err != nil {
+		return nil, fmt.Errorf("unknown .gitignore template '%s' (available: %s)", name, strings.Join(Gitignores(), ", "))
+	}
+	return content, nil
+}
@@ -4,7 +4,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"strconv"
+	"strings"
 
+	"github.com/okalexiiis/dwrk/internal/catalog"
+	"github.com/okalexiiis/dwrk/pkg/utils"
 	"gopkg.in/yaml.v3"
 )
 
@@ -16,13 +20,37 @@ const (
 	ConfigFileName = "config.yaml"
 )
 
+// Clone layouts: clones go directly in the projects directory, or under
+// <host>/<owner>/ inside it.
+const (
+	LayoutFlat = "flat"
+	LayoutHost = "host"
+)
+
 // Config represents the application's configuration structure.
 type Config struct {
-	ProjectsDir    string `yaml:"projects_dir"`    // Local directory where projects are stored.
-	TemplatesDir   string `yaml:"templates_dir"`   // Local directiry where templates are stored.
-	DefaultEditor  string `yaml:"default_editor"`  // Preferred editor (auto, code, nvim, vim, etc.)
-	GitHubUsername string `yaml:"github_username"` // Associated GitHub username.
-	UseSSH         bool   `yaml:"use_ssh"`         // Controls whether GitHub operations use SSH.
+	ProjectsDir    string   `yaml:"projects_dir"`             // Local directory where new projects are created.
+	ProjectsDirs   []string `yaml:"projects_dirs,omitempty"`  // Additional directories scanned for projects.
+	ScanDepth      int      `yaml:"scan_depth,omitempty"`     // How many directory levels are scanned for projects.
+	TemplatesDir   string   `yaml:"templates_dir"`            // Local directiry where templates are stored.
+	DefaultEditor  string   `yaml:"default_editor"`           // Preferred editor (auto, code, nvim, vim, etc.)
+	GitHubUsername string   `yaml:"github_username"`          // Associated GitHub username.
+	GitHubAPIURL   string   `yaml:"github_api_url,omitempty"` // GitHub REST API base URL (empty = api.github.com).
+	GitHubToken    string   `yaml:"github_token,omitempty"`   // GitHub API token (falls back to $GITHUB_TOKEN).
+	UseSSH         bool     `yaml:"use_ssh"`                  // Controls whether GitHub operations use SSH.
+
+	CloneLayout     string `yaml:"clone_layout,omitempty"` // Where clones go: "flat" (default) or "host".
+	DefaultHostName string `yaml:"default_host,omitempty"` // Host used by clone when none is given (default: github).
+	Hosts           []Host `yaml:"hosts,omitempty"`        // Additional or overridden Git hosts.
+
+	// Repository set up by `dwrk new --git`.
+	InitBranch        string   `yaml:"init_branch,omitempty"`         // Initial branch (default: Git's init.defaultBranch).
+	InitGitignore     []string `yaml:"init_gitignore,omitempty"`      // .gitignore templates from the catalog.
+	InitLicense       string   `yaml:"init_license,omitempty"`        // SPDX identifier of the license added as LICENSE.
+	LicenseHolder     string   `yaml:"license_holder,omitempty"`      // Copyright holder (default: the commit author name).
+	SkipInitialCommit bool     `yaml:"skip_initial_commit,omitempty"` // Leave new repositories without a commit.
+	GitUserName       string   `yaml:"git_user_name,omitempty"`       // Commit author of new repositories (default: Git's user.name).
+	GitUserEmail      string   `yaml:"git_user_email,omitempty"`      // Commit email of new repositories (default: Git's user.email).
 }
 
 // Default returns a new Config populated with default values.
@@ -31,6 +59,7 @@ func Default() *Config {
 	return &Config{
 		ProjectsDir:    filepath.Join(homeDir, "Projects"),
 		TemplatesDir:   filepath.Join(homeDir, ".config/dwrk/templates"),
+		ScanDepth:      1,
 		DefaultEditor:  "auto",
 		GitHubUsername: "username",
 		UseSSH:         true,
@@ -65,7 +94,8 @@ func Load() (*Config, error) {
 }
 
 // Save writes the current configuration to disk,
-// creating the configuration directory if needed.
+// creating the configuration directory if needed. The file may hold a GitHub
+// token, so it is only readable by its owner.
 func (c *Config) Save() error {
 	configPath := GetConfigPath()
 	configDir := filepath.Dir(configPath)
@@ -79,24 +109,64 @@ func (c *Config) Save() error {
 		return fmt.Errorf("failed to serialize configuration: %w", err)
 	}
 
-	if err := os.WriteFile(configPath, data, 0644); err != nil {
+	if err := os.WriteFile(configPath, data, 0600); err != nil {
 		return fmt.Errorf("failed to write configuration file: %w", err)
 	}
+	// WriteFile keeps the mode of an existing file, such as one created
+	// world-readable by an earlier version.
+	if err := os.Chmod(configPath, 0600); err != nil {
+		return fmt.Errorf("failed to restrict configuration file permissions: %w", err)
+	}
 
 	return nil
 }
 
+// ProjectRoots returns every directory scanned for projects with '~/' expanded.
+// ProjectsDir always comes first, followed by ProjectsDirs without duplicates.
+func (c *Config) ProjectRoots() []string {
+	var roots []string
+	seen := map[string]bool{}
+
+	for _, dir := range append([]string{c.ProjectsDir}, c.ProjectsDirs...) {
+		if dir == "" {
+			continue
+		}
+		dir = filepath.Clean(utils.ExpandPath(dir))
+		if seen[dir] {
+			continue
+		}
+		seen[dir] = true
+		roots = append(roots, dir)
+	}
+
+	return roots
+}
+
 // Set updates a configuration field by key and saves the result.
-// Expected keys: projects_dir, editor, github_username, use_ssh.
+// Expected keys: projects_dir, projects_dirs, scan_depth, editor,
+// github_username, github_api_url, github_token, use_ssh, clone_layout,
+// default_host, init_branch, init_gitignore, init_license, license_holder,
+// skip_initial_commit, git_user_name, git_user_email.
 func (c *Config) Set(key, value string) error {
 	switch key {
 	case "projects_dir":
-		// Expand '~/' to the user's home directory.
-		if len(value) >= 2 && value[:2] == "~/" {
-			homeDir, _ := os.UserHomeDir()
-			value = filepath.Join(homeDir, value[2:])
+		c.ProjectsDir = utils.ExpandPath(value)
+
+	case "projects_dirs":
+		// Comma-separated list of directories.
+		c.ProjectsDirs = nil
+		for _, dir := range strings.Split(value, ",") {
+			if dir = strings.TrimSpace(dir); dir != "" {
+				c.ProjectsDirs = append(c.ProjectsDirs, utils.ExpandPath(dir))
+			}
+		}
+
+	case "scan_depth":
+		depth, err := strconv.Atoi(value)
+		if err != nil || depth < 1 {
+			return fmt.Errorf("scan_depth must be a positive integer")
 		}
-		c.ProjectsDir = value
+		c.ScanDepth = depth
 
 	case "editor", "default_editor":
 		c.DefaultEditor = value
@@ -104,9 +174,65 @@ func (c *Config) Set(key, value string) error {
 	case "github_username", "username":
 		c.GitHubUsername = value
 
+	case "github_api_url":
+		c.GitHubAPIURL = strings.TrimSuffix(value, "/")
+
+	case "github_token", "token":
+		c.GitHubToken = value
+
 	case "use_ssh", "ssh":
 		c.UseSSH = value == "true" || value == "yes" || value == "1"
 
+	case "clone_layout":
+		if value != LayoutFlat && value != LayoutHost {
+			return fmt.Errorf("clone_layout must be '%s' or '%s'", LayoutFlat, LayoutHost)
+		}
+		c.CloneLayout = value
+
+	case "default_host":
+		if _, err := c.Host(value); err != nil {
+			return err
+		}
+		c.DefaultHostName = value
+
+	case "init_branch":
+		c.InitBranch = value
+
+	case "init_gitignore":
+		// Comma-separated list of catalog templates.
+		var names []string
+		for _, name := range strings.Split(value, ",") {
+			if name = strings.TrimSpace(name); name != "" {
+				names = append(names, name)
+			}
+		}
+		if _, err := catalog.Gitignore(names...); err != nil {
+			return err
+		}
+		c.InitGitignore = names
+
+	case "init_license":
+		if value != "" {
+			license, err := catalog.FindLicense(value)
+			if err != nil {
+				return err
+			}
+			value = license.ID
+		}
+		c.InitLicense = value
+
+	case "license_holder":
+		c.LicenseHolder = value
+
+	case "skip_initial_commit":
+		c.SkipInitialCommit = value == "true" || value == "yes" || value == "1"
+
+	case "git_user_name":
+		c.GitUserName = value
+
+	case "git_user_email":
+		c.GitUserEmail = value
+
 	default:
 		return fmt.Errorf("invalid configuration key: %s", key)
 	}
@@ -119,15 +245,41 @@ func (c *Config) Get(key string) (string, error) {
 	switch key {
 	case "projects_dir":
 		return c.ProjectsDir, nil
+	case "projects_dirs":
+		return strings.Join(c.ProjectsDirs, ","), nil
+	case "scan_depth":
+		return strconv.Itoa(c.ScanDepth), nil
 	case "editor", "default_editor":
 		return c.DefaultEditor, nil
 	case "github_username", "username":
 		return c.GitHubUsername, nil
+	case "github_api_url":
+		return c.GitHubAPIURL, nil
+	case "github_token", "token":
+		return c.GitHubToken, nil
 	case "use_ssh", "ssh":
 		if c.UseSSH {
 			return "true", nil
 		}
 		return "false", nil
+	case "clone_layout":
+		return c.CloneLayout, nil
+	case "default_host":
+		return c.DefaultHostName, nil
+	case "init_branch":
+		return c.InitBranch, nil
+	case "init_gitignore":
+		return strings.Join(c.InitGitignore, ","), nil
+	case "init_license":
+		return c.InitLicense, nil
+	case "license_holder":
+		return c.LicenseHolder, nil
+	case "skip_initial_commit":
+		return strconv.FormatBool(c.SkipInitialCommit), nil
+	case "git_user_name":
+		return c.GitUserName, nil
+	case "git_user_email":
+		return c.GitUserEmail, nil
 	default:
 		return "", fmt.Errorf("invalid configuration key: %s", key)
 	}
@@ -0,0 +1,179 @@
+package config
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Kinds of Git hosting services. The kind decides how repository URLs are built.
+const (
+	KindGitHub    = "github"
+	KindGitLab    = "gitlab"
+	KindGitea     = "gitea"
+	KindForgejo   = "forgejo"
+	KindBitbucket = "bitbucket"
+)
+
+// defaultHost is the host used when neither the command line nor the
+// configuration names one.
+const defaultHost = "github"
+
+// Host describes a Git hosting service repositories can be cloned from.
+type Host struct {
+	Name      string `yaml:"name"`                // Name used in `dwrk clone <name>:<repo>` and --host
+	Kind      string `yaml:"kind"`                // github, gitlab, gitea, forgejo or bitbucket
+	BaseURL   string `yaml:"base_url"`            // Web/HTTPS URL, e.g. https://gitlab.example.com
+	SSHHost   string `yaml:"ssh_host,omitempty"`  // SSH host name (default: host of base_url)
+	SSHPort   int    `yaml:"ssh_port,omitempty"`  // SSH port when not 22
+	Namespace string `yaml:"namespace,omitempty"` // Default owner, group or project of repositories
+
+	UserName  string `yaml:"user_name,omitempty"`  // Commit author of new projects for this host
+	UserEmail string `yaml:"user_email,omitempty"` // Commit email of new projects for this host
+}
+
+// builtinHosts are available without configuration; a configured host with
+// the same name takes precedence.
+var builtinHosts = []Host{
+	{Name: "github", Kind: KindGitHub, BaseURL: "https://github.com"},
+	{Name: "gitlab", Kind: KindGitLab, BaseURL: "https://gitlab.com"},
+	{Name: "bitbucket", Kind: KindBitbucket, BaseURL: "https://bitbucket.org"},
+	{Name: "codeberg", Kind: KindForgejo, BaseURL: "https://codeberg.org"},
+}
+
+// Host returns the host named name, looking at the configured hosts first and
+// then at the built-in ones. The built-in GitHub host uses github_username as
+// its namespace.
+func (c *Config) Host(name string) (*Host, error) {
+	for _, h := range c.Hosts {
+		if h.Name == name {
+			host := h
+			if err := host.validate(); err != nil {
+				return nil, err
+			}
+			return &host, nil
+		}
+	}
+
+	for _, h := range builtinHosts {
+		if h.Name == name {
+			host := h
+			if host.Kind == KindGitHub {
+				host.Namespace = c.GitHubUsername
+			}
+			return &host, nil
+		}
+	}
+
+	return nil, fmt.Errorf("unknown host '%s' (available: %s)", name, strings.Join(c.HostNames(), ", "))
+}
+
+// DefaultHost returns the host used when none is specified.
+func (c *Config) DefaultHost() (*Host, error) {
+	name := c.DefaultHostName
+	if name == "" {
+		name = defaultHost
+	}
+	return c.Host(name)
+}
+
+// Identity returns the commit author and email for new repositories of the
+// named host (the default host if empty). Values the host does not set come
+// from git_user_name and git_user_email; empty results leave Git's own
+// configuration in effect.
+func (c *Config) Identity(hostName string) (name, email string, err error) {
+	var host *Host
+	if hostName == "" {
+		host, err = c.DefaultHost()
+	} else {
+		host, err = c.Host(hostName)
+	}
+	if err != nil {
+		return "", "", err
+	}
+
+	name, email = host.UserName, host.UserEmail
+	if name == "" {
+		name = c.GitUserName
+	}
+	if email == "" {
+		email = c.GitUserEmail
+	}
+	return name, email, nil
+}
+
+// HostNames returns the names of every known host, sorted.
+func (c *Config) HostNames() []string {
+	seen := map[string]bool{}
+	var names []string
+
+	for _, hosts := range [][]Host{c.Hosts, builtinHosts} {
+		for _, h := range hosts {
+			if !seen[h.Name] {
+				seen[h.Name] = true
+				names = append(names, h.Name)
+			}
+		}
+	}
+
+	sort.Strings(names)
+	return names
+}
+
+// RepoURL builds the clone URL of the repository at path (e.g. "team/api" or
+// "group/sub/api") on the host, over HTTPS or SSH.
+func (h *Host) RepoURL(path string, https bool) string {
+	path = strings.Trim(path, "/")
+	base := strings.TrimSuffix(h.BaseURL, "/")
+
+	// Self-hosted Bitbucket (Server/Data Center) serves Git under /scm.
+	selfHostedBitbucket := h.Kind == KindBitbucket && h.webHost() != "bitbucket.org"
+
+	if https {
+		if selfHostedBitbucket {
+			return fmt.Sprintf("%s/scm/%s.git", base, path)
+		}
+		return fmt.Sprintf("%s/%s.git", base, path)
+	}
+
+	sshHost := h.SSHHost
+	if sshHost == "" {
+		sshHost = h.webHost()
+	}
+
+	if h.SSHPort != 0 && h.SSHPort != 22 {
+		return fmt.Sprintf("ssh://git@%s/%s.git", sshHost+":"+strconv.Itoa(h.SSHPort), path)
+	}
+	return fmt.Sprintf("git@%s:%s.git", sshHost, path)
+}
+
+// webHost returns the host name of BaseURL.
+func (h *Host) webHost() string {
+	u, err := url.Parse(h.BaseURL)
+	if err != nil || u.Host == "" {
+		return strings.TrimSuffix(h.BaseURL, "/")
+	}
+	return u.Hostname()
+}
+
+// validate checks a configured host.
+func (h *Host) validate() error {
+	switch h.Kind {
+	case KindGitHub, KindGitLab, KindGitea, KindForgejo, KindBitbucket:
+	case "":
+		return fmt.Errorf("host '%s' has no kind", h.Name)
+	default:
+		return fmt.Errorf("host '%s' has an unknown kind '%s'", h.Name, h.Kind)
+	}
+
+	if h.BaseURL == "" {
+		return fmt.Errorf("host '%s' has no base_url", h.Name)
+	}
+	if !strings.Contains(h.BaseURL, "://") {
+		h.BaseURL = "https://" + h.BaseURL
+	}
+
+	return nil
+}
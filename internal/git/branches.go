@@ -0,0 +1,86 @@
+package git
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+)
+
+// StaleBranch is a local branch that is safe to clean up.
+type StaleBranch struct {
+	Name   string
+	Merged bool // Fully merged into the default branch
+	Gone   bool // Its upstream branch was deleted from the remote
+}
+
+// DefaultBranch returns the default branch of the repository at dir: the
+// branch origin/HEAD points to, or else a local main or master branch. It
+// returns an empty string if none of them exists.
+func DefaultBranch(ctx context.Context, dir string) string {
+	out, err := exec.CommandContext(ctx, "git", "-C", dir, "symbolic-ref", "--quiet", "--short", "refs/remotes/origin/HEAD").Output()
+	if err == nil {
+		if branch, ok := strings.CutPrefix(strings.TrimSpace(string(out)), "origin/"); ok && branchExists(ctx, dir, branch) {
+			return branch
+		}
+	}
+
+	for _, branch := range []string{"main", "master"} {
+		if branchExists(ctx, dir, branch) {
+			return branch
+		}
+	}
+	return ""
+}
+
+// StaleBranches returns the local branches of the repository at dir that are
+// merged into base or whose upstream is gone. The base branch and branches
+// checked out in any worktree, including the current one, are never returned.
+// When base is empty only branches with a gone upstream are considered.
+func StaleBranches(ctx context.Context, dir, base string) ([]StaleBranch, error) {
+	out, err := output(ctx, dir, "for-each-ref",
+		"--format=%(refname:short)%00%(upstream:track)%00%(worktreepath)", "refs/heads")
+	if err != nil {
+		return nil, err
+	}
+
+	merged := map[string]bool{}
+	if base != "" {
+		out, err := output(ctx, dir, "for-each-ref", "--format=%(refname:short)", "--merged", base, "refs/heads")
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range strings.Fields(string(out)) {
+			merged[name] = true
+		}
+	}
+
+	var branches []StaleBranch
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		fields := strings.Split(line, "\x00")
+		if len(fields) != 3 || fields[0] == base || fields[2] != "" {
+			continue
+		}
+
+		branch := StaleBranch{
+			Name:   fields[0],
+			Merged: merged[fields[0]],
+			Gone:   fields[1] == "[gone]",
+		}
+		if branch.Merged || branch.Gone {
+			branches = append(branches, branch)
+		}
+	}
+
+	return branches, nil
+}
+
+// DeleteBranch deletes a local branch of the repository at dir, whether or
+// not Git considers it merged; select the branches with StaleBranches.
+func DeleteBranch(ctx context.Context, dir, name string) error {
+	return run(ctx, dir, "branch", "-D", name)
+}
+
+// branchExists reports whether the repository at dir has a local branch.
+func branchExists(ctx context.Context, dir, name string) bool {
+	return exec.CommandContext(ctx, "git", "-C", dir, "show-ref", "--verify", "--quiet", "refs/heads/"+name).Run() == nil
+}
@@ -1,10 +1,15 @@
 package git
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/okalexiiis/dwrk/pkg/utils"
 )
@@ -12,94 +17,127 @@ import (
 // Cloner provides utilities for cloning Git repositories.
 type Cloner struct{}
 
+// ErrDestinationNotEmpty is returned by Clone when the destination directory
+// already exists and is not empty.
+var ErrDestinationNotEmpty = errors.New("destination already exists and is not empty")
+
+// CloneOptions defines optional behaviors when cloning a repository.
+type CloneOptions struct {
+	Branch            string   // Branch or tag to check out instead of the remote HEAD
+	Depth             int      // Create a shallow clone with this many commits (0 = full history)
+	RecurseSubmodules bool     // Initialize and clone submodules
+	Sparse            []string // Only check out these paths (sparse checkout, cone mode)
+	Quiet             bool     // Suppress Git progress output
+
+	Stdout io.Writer // Destination of Git output (default os.Stdout)
+	Stderr io.Writer // Destination of Git progress and errors (default os.Stderr)
+}
+
 // NewCloner creates and returns a new Cloner instance.
 func NewCloner() *Cloner {
 	return &Cloner{}
 }
 
-// Clone clones a Git repository into the specified directory.
+// Clone clones a Git repository into destDir, which must not exist or be an
+// empty directory. Parent directories are created automatically.
 //
-// If the target directory does not exist, it is created automatically.
-// Progress output from Git is streamed directly to stdout/stderr.
-// The returned value is the absolute path of the cloned repository.
-func (c *Cloner) Clone(repoURL, targetDir string) (string, error) {
+// The destination is passed explicitly to git and checked afterwards, so the
+// returned absolute path is always the root of the new checkout.
+// Progress output from Git is streamed to the configured writers.
+func (c *Cloner) Clone(ctx context.Context, repoURL, destDir string, opts CloneOptions) (string, error) {
 	if !c.IsGitInstalled() {
 		return "", fmt.Errorf("git is not installed on this system")
 	}
 
-	if err := os.MkdirAll(targetDir, 0755); err != nil {
-		return "", fmt.Errorf("failed creating target directory: %w", err)
+	dest, err := filepath.Abs(destDir)
+	if err != nil {
+		return "", fmt.Errorf("invalid destination %s: %w", destDir, err)
 	}
 
-	cmd := exec.Command("git", "clone", repoURL)
-	cmd.Dir = targetDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	if empty, err := utils.IsEmptyDir(dest); err != nil {
+		return "", fmt.Errorf("failed checking destination: %w", err)
+	} else if !empty {
+		return "", fmt.Errorf("%w: %s", ErrDestinationNotEmpty, dest)
+	}
 
-	if err := cmd.Run(); err != nil {
-		return "", utils.ParseGitError(err)
+	if err := os.MkdirAll(filepath.Dir(dest), 0755); err != nil {
+		return "", fmt.Errorf("failed creating target directory: %w", err)
 	}
 
-	repoName := utils.ExtractRepoNameFromURL(repoURL)
-	clonedPath := filepath.Join(targetDir, repoName)
+	args := []string{"clone"}
+	if opts.Quiet {
+		// --quiet does not silence the advice printed when cloning a tag.
+		args = []string{"-c", "advice.detachedHead=false", "clone", "--quiet"}
+	}
+	if opts.Branch != "" {
+		args = append(args, "--branch", opts.Branch)
+	}
+	if opts.Depth > 0 {
+		args = append(args, "--depth", strconv.Itoa(opts.Depth))
+	}
+	if len(opts.Sparse) > 0 {
+		// Skip blobs outside the sparse paths instead of downloading everything.
+		args = append(args, "--sparse", "--filter=blob:none")
+	}
+	if opts.RecurseSubmodules {
+		args = append(args, "--recurse-submodules")
+	}
+	args = append(args, "--", repoURL, dest)
 
-	return clonedPath, nil
-}
+	if err := c.run(ctx, filepath.Dir(dest), opts, args...); err != nil {
+		return "", err
+	}
 
-// CloneWithDepth performs a shallow clone of a Git repository.
-//
-// The clone will contain only the latest commit history up to the given depth.
-// This is useful for performance-sensitive tasks such as CI or large repositories.
-func (c *Cloner) CloneWithDepth(repoURL, targetDir string, depth int) (string, error) {
-	if !c.IsGitInstalled() {
-		return "", fmt.Errorf("git is not installed on this system")
+	if err := verifyCheckout(dest); err != nil {
+		return "", err
 	}
 
-	if err := os.MkdirAll(targetDir, 0755); err != nil {
-		return "", fmt.Errorf("failed creating target directory: %w", err)
+	if len(opts.Sparse) > 0 {
+		sparseArgs := append([]string{"sparse-checkout", "set"}, opts.Sparse...)
+		if err := c.run(ctx, dest, opts, sparseArgs...); err != nil {
+			return "", fmt.Errorf("failed to configure sparse checkout: %w", err)
+		}
 	}
 
-	cmd := exec.Command("git", "clone", "--depth", fmt.Sprintf("%d", depth), repoURL)
-	cmd.Dir = targetDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	return dest, nil
+}
 
-	if err := cmd.Run(); err != nil {
-		return "", utils.ParseGitError(err)
+// verifyCheckout checks that dir is the top level of a Git working tree.
+func verifyCheckout(dir string) error {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "--show-toplevel").Output()
+	if err != nil {
+		return fmt.Errorf("clone did not produce a repository at %s", dir)
 	}
 
-	repoName := utils.ExtractRepoNameFromURL(repoURL)
-	clonedPath := filepath.Join(targetDir, repoName)
+	top, err1 := filepath.EvalSymlinks(strings.TrimSpace(string(out)))
+	want, err2 := filepath.EvalSymlinks(dir)
+	if err1 != nil || err2 != nil || top != want {
+		return fmt.Errorf("clone did not produce a repository at %s", dir)
+	}
 
-	return clonedPath, nil
+	return nil
 }
 
-// CloneBranch clones a specific branch from a Git repository.
-//
-// If the branch does not exist, the Git command will fail and the resulting
-// error will be parsed into a more readable error message.
-func (c *Cloner) CloneBranch(repoURL, targetDir, branch string) (string, error) {
-	if !c.IsGitInstalled() {
-		return "", fmt.Errorf("git is not installed on this system")
+// run executes a git command in dir, streaming its output.
+func (c *Cloner) run(ctx context.Context, dir string, opts CloneOptions, args ...string) error {
+	stdout, stderr := opts.Stdout, opts.Stderr
+	if stdout == nil {
+		stdout = os.Stdout
 	}
-
-	if err := os.MkdirAll(targetDir, 0755); err != nil {
-		return "", fmt.Errorf("failed creating target directory: %w", err)
+	if stderr == nil {
+		stderr = os.Stderr
 	}
 
-	cmd := exec.Command("git", "clone", "-b", branch, repoURL)
-	cmd.Dir = targetDir
-	cmd.Stdout = os.Stdout
-	cmd.Stderr = os.Stderr
+	cmd := exec.CommandContext(ctx, "git", args...)
+	cmd.Dir = dir
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
 
 	if err := cmd.Run(); err != nil {
-		return "", utils.ParseGitError(err)
+		return utils.ParseGitError(err)
 	}
 
-	repoName := utils.ExtractRepoNameFromURL(repoURL)
-	clonedPath := filepath.Join(targetDir, repoName)
-
-	return clonedPath, nil
+	return nil
 }
 
 // IsGitInstalled checks whether the git binary is available in the system PATH.
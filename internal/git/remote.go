@@ -0,0 +1,28 @@
+package git
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+)
+
+// RemoteURL returns the URL of the named remote of the repository at dir, or
+// an empty string if the remote is not configured.
+func RemoteURL(dir, remote string) string {
+	out, err := exec.Command("git", "-C", dir, "remote", "get-url", remote).Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// AddRemote adds a remote to the repository at dir and fetches it.
+func AddRemote(dir, remote, url string) error {
+	if out, err := exec.Command("git", "-C", dir, "remote", "add", remote, url).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed adding remote '%s': %s", remote, strings.TrimSpace(string(out)))
+	}
+	if out, err := exec.Command("git", "-C", dir, "fetch", "--quiet", remote).CombinedOutput(); err != nil {
+		return fmt.Errorf("failed fetching remote '%s': %s", remote, strings.TrimSpace(string(out)))
+	}
+	return nil
+}
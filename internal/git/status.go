@@ -0,0 +1,105 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Status summarizes the state of a working tree.
+type Status struct {
+	Branch     string    // Current branch; empty on a detached HEAD
+	Detached   bool      // HEAD does not point to a branch
+	Commit     string    // Commit HEAD points to; empty before the first commit
+	Upstream   string    // Tracking branch, e.g. origin/main; empty if none
+	Gone       bool      // The tracking branch no longer exists on the remote
+	Ahead      int       // Commits not pushed to the upstream
+	Behind     int       // Upstream commits not merged locally
+	Dirty      int       // Changed, unmerged and untracked paths
+	Untracked  int       // Untracked paths, also counted in Dirty
+	Stashes    int       // Entries in the stash
+	LastCommit time.Time // Committer date of HEAD; zero before the first commit
+}
+
+// GetStatus reads the status of the repository at dir using
+// `git status --porcelain=v2 --branch --show-stash`.
+func GetStatus(ctx context.Context, dir string) (*Status, error) {
+	cmd := exec.CommandContext(ctx, "git", "-C", dir, "status", "--porcelain=v2", "--branch", "--show-stash")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return nil, fmt.Errorf("git status failed: %s", msg)
+		}
+		return nil, fmt.Errorf("git status failed: %w", err)
+	}
+
+	status := parseStatus(out)
+
+	if status.Commit != "" {
+		out, err := exec.CommandContext(ctx, "git", "-C", dir, "log", "-1", "--format=%ct").Output()
+		if err == nil {
+			if ts, err := strconv.ParseInt(strings.TrimSpace(string(out)), 10, 64); err == nil {
+				status.LastCommit = time.Unix(ts, 0)
+			}
+		}
+	}
+
+	return status, nil
+}
+
+// parseStatus parses the output of `git status --porcelain=v2 --branch --show-stash`.
+func parseStatus(out []byte) *Status {
+	status := &Status{}
+	hasAB := false
+
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		header, ok := strings.CutPrefix(line, "# ")
+		if !ok {
+			// Ordinary (1), renamed (2), unmerged (u) and untracked (?) entries.
+			if line != "" && !strings.HasPrefix(line, "!") {
+				status.Dirty++
+			}
+			if strings.HasPrefix(line, "? ") {
+				status.Untracked++
+			}
+			continue
+		}
+
+		key, value, _ := strings.Cut(header, " ")
+		switch key {
+		case "branch.oid":
+			if value != "(initial)" {
+				status.Commit = value
+			}
+		case "branch.head":
+			if value == "(detached)" {
+				status.Detached = true
+			} else {
+				status.Branch = value
+			}
+		case "branch.upstream":
+			status.Upstream = value
+		case "branch.ab":
+			hasAB = true
+			fmt.Sscanf(value, "+%d -%d", &status.Ahead, &status.Behind)
+		case "stash":
+			status.Stashes, _ = strconv.Atoi(value)
+		}
+	}
+
+	// Git omits the ahead/behind line when the upstream ref is missing.
+	status.Gone = status.Upstream != "" && !hasAB
+
+	return status
+}
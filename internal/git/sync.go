@@ -0,0 +1,56 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// Fetch runs `git fetch --prune` in the repository at dir.
+func Fetch(ctx context.Context, dir string) error {
+	return run(ctx, dir, "fetch", "--prune", "--quiet")
+}
+
+// FastForward merges the upstream of the current branch into it, failing
+// if that cannot be done as a fast-forward.
+func FastForward(ctx context.Context, dir string) error {
+	return run(ctx, dir, "merge", "--ff-only", "--quiet", "@{upstream}")
+}
+
+// run executes a git command in dir without prompting for credentials and
+// returns Git's own message when it fails.
+func run(ctx context.Context, dir string, args ...string) error {
+	_, err := output(ctx, dir, args...)
+	return err
+}
+
+// output is like run but also returns the standard output of the command.
+func output(ctx context.Context, dir string, args ...string) ([]byte, error) {
+	cmd := exec.CommandContext(ctx, "git", append([]string{"-C", dir}, args...)...)
+	cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+
+	out, err := cmd.Output()
+	if err == nil {
+		return out, nil
+	}
+
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return nil, fmt.Errorf("git %s timed out", args[0])
+	}
+
+	for _, line := range strings.Split(stderr.String(), "\n") {
+		if msg, ok := strings.CutPrefix(strings.TrimSpace(line), "fatal: "); ok {
+			return nil, errors.New(msg)
+		}
+	}
+	if msg := strings.TrimSpace(stderr.String()); msg != "" {
+		return nil, errors.New(msg)
+	}
+	return nil, fmt.Errorf("git %s failed: %w", args[0], err)
+}
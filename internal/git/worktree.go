@@ -0,0 +1,101 @@
+package git
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"strings"
+)
+
+// Worktree is a working tree attached to a repository, as reported by
+// `git worktree list --porcelain`.
+type Worktree struct {
+	Path     string
+	Branch   string // Checked out branch; empty on a detached HEAD
+	Commit   string
+	Main     bool // The repository's main working tree
+	Detached bool
+	Prunable bool // The directory is gone and `git worktree prune` would remove it
+}
+
+// Worktrees lists the working trees of the repository at dir, starting with
+// the main one.
+func Worktrees(ctx context.Context, dir string) ([]Worktree, error) {
+	out, err := output(ctx, dir, "worktree", "list", "--porcelain")
+	if err != nil {
+		return nil, err
+	}
+
+	var worktrees []Worktree
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		key, value, _ := strings.Cut(scanner.Text(), " ")
+		if key == "worktree" {
+			worktrees = append(worktrees, Worktree{Path: value, Main: len(worktrees) == 0})
+			continue
+		}
+		if len(worktrees) == 0 {
+			continue
+		}
+
+		wt := &worktrees[len(worktrees)-1]
+		switch key {
+		case "HEAD":
+			wt.Commit = value
+		case "branch":
+			wt.Branch = strings.TrimPrefix(value, "refs/heads/")
+		case "detached":
+			wt.Detached = true
+		case "prunable":
+			wt.Prunable = true
+		}
+	}
+
+	return worktrees, nil
+}
+
+// AddWorktree checks out branch in a new working tree at path. An existing
+// local branch is used as is; a branch only found on a remote is created
+// tracking it; otherwise a new branch is started at base (HEAD if empty).
+func AddWorktree(ctx context.Context, dir, path, branch, base string) error {
+	if branchExists(ctx, dir, branch) || (base == "" && remoteBranchExists(ctx, dir, branch)) {
+		return run(ctx, dir, "worktree", "add", "--quiet", path, branch)
+	}
+
+	args := []string{"worktree", "add", "--quiet", "-b", branch, path}
+	if base != "" {
+		args = append(args, base)
+	}
+	return run(ctx, dir, args...)
+}
+
+// RemoveWorktree removes the working tree at path. With force, uncommitted
+// changes in it are discarded.
+func RemoveWorktree(ctx context.Context, dir, path string, force bool) error {
+	args := []string{"worktree", "remove", path}
+	if force {
+		args = []string{"worktree", "remove", "--force", path}
+	}
+	return run(ctx, dir, args...)
+}
+
+// PruneWorktrees removes the metadata of working trees whose directory no
+// longer exists.
+func PruneWorktrees(ctx context.Context, dir string) error {
+	return run(ctx, dir, "worktree", "prune")
+}
+
+// remoteBranchExists reports whether any remote of the repository at dir has
+// a branch with the given name.
+func remoteBranchExists(ctx context.Context, dir, name string) bool {
+	out, err := output(ctx, dir, "for-each-ref", "--format=%(refname:lstrip=3)", "refs/remotes")
+	if err != nil {
+		return false
+	}
+	for _, ref := range strings.Fields(string(out)) {
+		if ref == name {
+			return true
+		}
+	}
+	return false
+}
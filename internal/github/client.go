@@ -0,0 +1,347 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/okalexiiis/dwrk/internal/config"
+)
+
+// DefaultAPIURL is the base URL of the public GitHub REST API.
+const DefaultAPIURL = "https://api.github.com"
+
+// TokenEnvVar is the environment variable read when no token is configured.
+const TokenEnvVar = "GITHUB_TOKEN"
+
+const (
+	perPage      = 100             // Page size requested from list endpoints (the API maximum)
+	maxRetries   = 3               // Retries of a rate-limited request
+	maxRetryWait = 2 * time.Minute // Longest wait for a rate limit to reset before giving up
+)
+
+// nextLinkPattern extracts the URL of the next page from a Link header.
+var nextLinkPattern = regexp.MustCompile(`<([^>]+)>;\s*rel="next"`)
+
+// Client is a small GitHub REST API client.
+type Client struct {
+	baseURL    string
+	token      string
+	httpClient *http.Client
+}
+
+// Repository is the subset of the GitHub repository resource used by dwrk.
+type Repository struct {
+	Name          string   `json:"name"`
+	FullName      string   `json:"full_name"`
+	Description   string   `json:"description"`
+	Language      string   `json:"language"`
+	Topics        []string `json:"topics"`
+	Private       bool     `json:"private"`
+	Fork          bool     `json:"fork"`
+	Archived      bool     `json:"archived"`
+	DefaultBranch string   `json:"default_branch"`
+	CloneURL      string   `json:"clone_url"`
+	SSHURL        string   `json:"ssh_url"`
+	HTMLURL       string   `json:"html_url"`
+}
+
+// CreateRepoOptions describes a repository to create.
+type CreateRepoOptions struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Private     bool   `json:"private"`
+	AutoInit    bool   `json:"auto_init,omitempty"` // Create an initial commit with a README
+}
+
+// APIError is returned when the API answers with an unexpected status.
+type APIError struct {
+	StatusCode int
+	Message    string
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("github API error (%d): %s", e.StatusCode, e.Message)
+}
+
+// RateLimitError is returned when the rate limit is exhausted and does not
+// reset soon enough to wait for it.
+type RateLimitError struct {
+	Reset time.Time
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("github API rate limit exceeded; resets at %s", e.Reset.Format(time.Kitchen))
+}
+
+// IsNotFound reports whether err is a 404 response from the API.
+func IsNotFound(err error) bool {
+	apiErr, ok := err.(*APIError)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// NewClient creates a client for the API at baseURL (DefaultAPIURL if empty).
+// The token is optional; without it only public data is reachable.
+func NewClient(baseURL, token string) *Client {
+	if baseURL == "" {
+		baseURL = DefaultAPIURL
+	}
+
+	return &Client{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		token:      token,
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// NewClientFromConfig creates a client using the configured API URL and token,
+// falling back to the GITHUB_TOKEN environment variable.
+func NewClientFromConfig(cfg *config.Config) *Client {
+	token := cfg.GitHubToken
+	if token == "" {
+		token = os.Getenv(TokenEnvVar)
+	}
+	return NewClient(cfg.GitHubAPIURL, token)
+}
+
+// HasToken reports whether requests are authenticated.
+func (c *Client) HasToken() bool {
+	return c.token != ""
+}
+
+// ListOrgRepos returns every repository of an organization.
+func (c *Client) ListOrgRepos(ctx context.Context, org string) ([]Repository, error) {
+	return c.listRepos(ctx, "/orgs/"+url.PathEscape(org)+"/repos")
+}
+
+// ListUserRepos returns every repository owned by a user. Only public
+// repositories are listed, except for the owner of the token, whose private
+// repositories are included as well.
+func (c *Client) ListUserRepos(ctx context.Context, user string) ([]Repository, error) {
+	if c.HasToken() {
+		login, err := c.AuthenticatedUser(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if strings.EqualFold(login, user) {
+			return c.listRepos(ctx, "/user/repos?affiliation=owner")
+		}
+	}
+	return c.listRepos(ctx, "/users/"+url.PathEscape(user)+"/repos")
+}
+
+// AuthenticatedUser returns the login of the token's owner. It requires a token.
+func (c *Client) AuthenticatedUser(ctx context.Context) (string, error) {
+	var user struct {
+		Login string `json:"login"`
+	}
+	if _, err := c.do(ctx, http.MethodGet, c.baseURL+"/user", nil, &user); err != nil {
+		return "", err
+	}
+	return user.Login, nil
+}
+
+// GetRepo returns the metadata of owner/name.
+func (c *Client) GetRepo(ctx context.Context, owner, name string) (*Repository, error) {
+	var repo Repository
+	path := "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(name)
+	if _, err := c.do(ctx, http.MethodGet, c.baseURL+path, nil, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// CreateRepo creates a repository in org, or for the authenticated user when
+// org is empty. It requires a token.
+func (c *Client) CreateRepo(ctx context.Context, org string, opts CreateRepoOptions) (*Repository, error) {
+	if !c.HasToken() {
+		return nil, fmt.Errorf("creating a repository requires a GitHub token (set github_token or %s)", TokenEnvVar)
+	}
+
+	path := "/user/repos"
+	if org != "" {
+		path = "/orgs/" + url.PathEscape(org) + "/repos"
+	}
+
+	var repo Repository
+	if _, err := c.do(ctx, http.MethodPost, c.baseURL+path, opts, &repo); err != nil {
+		return nil, err
+	}
+	return &repo, nil
+}
+
+// listRepos fetches all pages of a repository list endpoint, which may carry
+// its own query parameters, following the Link headers returned by the API.
+func (c *Client) listRepos(ctx context.Context, path string) ([]Repository, error) {
+	var repos []Repository
+
+	sep := "?"
+	if strings.Contains(path, "?") {
+		sep = "&"
+	}
+	next := fmt.Sprintf("%s%s%sper_page=%d", c.baseURL, path, sep, perPage)
+	for next != "" {
+		var batch []Repository
+		var err error
+
+		next, err = c.do(ctx, http.MethodGet, next, nil, &batch)
+		if err != nil {
+			return nil, err
+		}
+		repos = append(repos, batch...)
+
+		// The token is sent with every page, so links must not lead it away
+		// from the API.
+		if next != "" && !c.isAPIURL(next) {
+			return nil, fmt.Errorf("refusing to follow pagination link outside %s: %s", c.baseURL, next)
+		}
+	}
+
+	return repos, nil
+}
+
+// isAPIURL reports whether endpoint has the scheme and host of the API.
+func (c *Client) isAPIURL(endpoint string) bool {
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return false
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(u.Scheme, base.Scheme) && strings.EqualFold(u.Host, base.Host)
+}
+
+// do performs a request, retrying while the rate limit resets, and decodes
+// the JSON response into out. It returns the URL of the next page, if any.
+func (c *Client) do(ctx context.Context, method, endpoint string, body, out any) (string, error) {
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return "", err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		resp, err := c.send(ctx, method, endpoint, payload)
+		if err != nil {
+			return "", err
+		}
+
+		if wait, limited := rateLimitWait(resp); limited {
+			resp.Body.Close()
+
+			if attempt >= maxRetries || wait > maxRetryWait {
+				return "", &RateLimitError{Reset: time.Now().Add(wait)}
+			}
+
+			select {
+			case <-time.After(wait):
+				continue
+			case <-ctx.Done():
+				return "", ctx.Err()
+			}
+		}
+
+		defer resp.Body.Close()
+
+		if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+			return "", decodeError(resp)
+		}
+
+		if out != nil {
+			if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+				return "", fmt.Errorf("failed to decode github response: %w", err)
+			}
+		}
+
+		return nextPage(resp.Header.Get("Link")), nil
+	}
+}
+
+// send issues a single request with the API headers set.
+func (c *Client) send(ctx context.Context, method, endpoint string, payload []byte) (*http.Response, error) {
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if payload != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("github request failed: %w", err)
+	}
+	return resp, nil
+}
+
+// rateLimitWait reports whether resp was rejected by a primary or secondary
+// rate limit, and how long to wait before retrying.
+func rateLimitWait(resp *http.Response) (time.Duration, bool) {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		reset, err := strconv.ParseInt(resp.Header.Get("X-RateLimit-Reset"), 10, 64)
+		if err != nil {
+			return time.Minute, true
+		}
+		return max(time.Until(time.Unix(reset, 0)), 0), true
+	}
+
+	if resp.StatusCode == http.StatusTooManyRequests {
+		return time.Minute, true
+	}
+
+	return 0, false
+}
+
+// decodeError builds an APIError from an error response.
+func decodeError(resp *http.Response) error {
+	var apiErr struct {
+		Message string `json:"message"`
+	}
+	json.NewDecoder(resp.Body).Decode(&apiErr)
+	if apiErr.Message == "" {
+		apiErr.Message = resp.Status
+	}
+	return &APIError{StatusCode: resp.StatusCode, Message: apiErr.Message}
+}
+
+// nextPage returns the URL of the next page from a Link header.
+func nextPage(link string) string {
+	if match := nextLinkPattern.FindStringSubmatch(link); match != nil {
+		return match[1]
+	}
+	return ""
+}
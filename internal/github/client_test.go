@@ -0,0 +1,300 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/okalexiiis/dwrk/internal/config"
+)
+
+// writeJSON writes v as the JSON body of a response with the given status.
+func writeJSON(t *testing.T, w http.ResponseWriter, status int, v any) {
+	t.Helper()
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		t.Errorf("encoding response: %v", err)
+	}
+}
+
+func TestListOrgReposFollowsLinkHeader(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/orgs/acme/repos" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("per_page"); got != strconv.Itoa(perPage) {
+			t.Errorf("per_page = %q, want %d", got, perPage)
+		}
+
+		switch page := r.URL.Query().Get("page"); page {
+		case "":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/orgs/acme/repos?per_page=%d&page=2>; rel="next", <%s/orgs/acme/repos?per_page=%d&page=3>; rel="last"`, srv.URL, perPage, srv.URL, perPage))
+			writeJSON(t, w, http.StatusOK, []Repository{{Name: "api"}, {Name: "web"}})
+		case "2":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/orgs/acme/repos?per_page=%d&page=3>; rel="next"`, srv.URL, perPage))
+			writeJSON(t, w, http.StatusOK, []Repository{{Name: "cli"}})
+		case "3":
+			w.Header().Set("Link", fmt.Sprintf(`<%s/orgs/acme/repos?per_page=%d&page=1>; rel="first"`, srv.URL, perPage))
+			writeJSON(t, w, http.StatusOK, []Repository{{Name: "docs"}})
+		default:
+			t.Errorf("unexpected page %q", page)
+		}
+	}))
+	defer srv.Close()
+
+	repos, err := NewClient(srv.URL, "").ListOrgRepos(context.Background(), "acme")
+	if err != nil {
+		t.Fatalf("ListOrgRepos: %v", err)
+	}
+
+	var names []string
+	for _, repo := range repos {
+		names = append(names, repo.Name)
+	}
+	if want := "[api web cli docs]"; fmt.Sprint(names) != want {
+		t.Errorf("repos = %v, want %s", names, want)
+	}
+}
+
+func TestListOrgReposRejectsForeignLinkHeader(t *testing.T) {
+	var foreignCalls atomic.Int32
+	foreign := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		foreignCalls.Add(1)
+		writeJSON(t, w, http.StatusOK, []Repository{})
+	}))
+	defer foreign.Close()
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Link", fmt.Sprintf(`<%s/orgs/acme/repos?page=2>; rel="next"`, foreign.URL))
+		writeJSON(t, w, http.StatusOK, []Repository{{Name: "api"}})
+	}))
+	defer srv.Close()
+
+	if _, err := NewClient(srv.URL, "token").ListOrgRepos(context.Background(), "acme"); err == nil {
+		t.Fatal("ListOrgRepos followed a link to another host")
+	}
+	if got := foreignCalls.Load(); got != 0 {
+		t.Errorf("requests to the other host = %d, want 0", got)
+	}
+}
+
+func TestListUserRepos(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    string
+		user     string
+		wantPath string
+	}{
+		{name: "without token", user: "octocat", wantPath: "/users/octocat/repos"},
+		{name: "token owner", token: "token", user: "OctoCat", wantPath: "/user/repos"},
+		{name: "other user", token: "token", user: "hubot", wantPath: "/users/hubot/repos"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var listed string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.URL.Path == "/user" {
+					writeJSON(t, w, http.StatusOK, map[string]string{"login": "octocat"})
+					return
+				}
+				listed = r.URL.Path
+				if r.URL.Path == "/user/repos" && r.URL.Query().Get("affiliation") != "owner" {
+					t.Errorf("affiliation = %q, want owner", r.URL.Query().Get("affiliation"))
+				}
+				writeJSON(t, w, http.StatusOK, []Repository{{Name: "api"}})
+			}))
+			defer srv.Close()
+
+			if _, err := NewClient(srv.URL, tt.token).ListUserRepos(context.Background(), tt.user); err != nil {
+				t.Fatalf("ListUserRepos: %v", err)
+			}
+			if listed != tt.wantPath {
+				t.Errorf("listed %s, want %s", listed, tt.wantPath)
+			}
+		})
+	}
+}
+
+func TestDoRetriesAfterRateLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		limited func(w http.ResponseWriter)
+	}{
+		{
+			name: "Retry-After",
+			limited: func(w http.ResponseWriter) {
+				w.Header().Set("Retry-After", "0")
+				w.WriteHeader(http.StatusTooManyRequests)
+			},
+		},
+		{
+			name: "X-RateLimit-Reset",
+			limited: func(w http.ResponseWriter) {
+				w.Header().Set("X-RateLimit-Remaining", "0")
+				w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Unix(), 10))
+				w.WriteHeader(http.StatusForbidden)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if calls.Add(1) <= 2 {
+					tt.limited(w)
+					return
+				}
+				writeJSON(t, w, http.StatusOK, Repository{Name: "api"})
+			}))
+			defer srv.Close()
+
+			repo, err := NewClient(srv.URL, "").GetRepo(context.Background(), "acme", "api")
+			if err != nil {
+				t.Fatalf("GetRepo: %v", err)
+			}
+			if repo.Name != "api" {
+				t.Errorf("repo = %q, want api", repo.Name)
+			}
+			if got := calls.Load(); got != 3 {
+				t.Errorf("requests = %d, want 3", got)
+			}
+		})
+	}
+}
+
+func TestDoGivesUpOnLongRateLimit(t *testing.T) {
+	var calls atomic.Int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", strconv.Itoa(int((maxRetryWait + time.Minute).Seconds())))
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL, "").GetRepo(context.Background(), "acme", "api")
+
+	var rateErr *RateLimitError
+	if !errors.As(err, &rateErr) {
+		t.Fatalf("err = %v, want *RateLimitError", err)
+	}
+	if got := calls.Load(); got != 1 {
+		t.Errorf("requests = %d, want 1", got)
+	}
+}
+
+func TestDoReturnsAPIError(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(t, w, http.StatusNotFound, map[string]string{"message": "Not Found"})
+	}))
+	defer srv.Close()
+
+	_, err := NewClient(srv.URL, "").GetRepo(context.Background(), "acme", "missing")
+	if !IsNotFound(err) {
+		t.Fatalf("err = %v, want a 404 APIError", err)
+	}
+}
+
+func TestNewClientFromConfigTokenPrecedence(t *testing.T) {
+	tests := []struct {
+		name     string
+		cfgToken string
+		envToken string
+		wantAuth string
+	}{
+		{name: "config wins", cfgToken: "from-config", envToken: "from-env", wantAuth: "Bearer from-config"},
+		{name: "environment fallback", envToken: "from-env", wantAuth: "Bearer from-env"},
+		{name: "no token", wantAuth: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var auth string
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				auth = r.Header.Get("Authorization")
+				writeJSON(t, w, http.StatusOK, Repository{Name: "api"})
+			}))
+			defer srv.Close()
+
+			t.Setenv(TokenEnvVar, tt.envToken)
+			client := NewClientFromConfig(&config.Config{GitHubAPIURL: srv.URL, GitHubToken: tt.cfgToken})
+
+			if _, err := client.GetRepo(context.Background(), "acme", "api"); err != nil {
+				t.Fatalf("GetRepo: %v", err)
+			}
+			if auth != tt.wantAuth {
+				t.Errorf("Authorization = %q, want %q", auth, tt.wantAuth)
+			}
+			if client.HasToken() != (tt.wantAuth != "") {
+				t.Errorf("HasToken = %v", client.HasToken())
+			}
+		})
+	}
+}
+
+func TestCreateRepo(t *testing.T) {
+	tests := []struct {
+		name     string
+		org      string
+		wantPath string
+	}{
+		{name: "user", wantPath: "/user/repos"},
+		{name: "org", org: "acme", wantPath: "/orgs/acme/repos"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if r.Method != http.MethodPost {
+					t.Errorf("method = %s, want POST", r.Method)
+				}
+				if r.URL.Path != tt.wantPath {
+					t.Errorf("path = %s, want %s", r.URL.Path, tt.wantPath)
+				}
+
+				var opts CreateRepoOptions
+				if err := json.NewDecoder(r.Body).Decode(&opts); err != nil {
+					t.Errorf("decoding request: %v", err)
+				}
+				if opts.Name != "api" || !opts.Private {
+					t.Errorf("request = %+v, want private repository api", opts)
+				}
+
+				writeJSON(t, w, http.StatusCreated, Repository{
+					Name:   opts.Name,
+					SSHURL: "git@github.com:acme/api.git",
+				})
+			}))
+			defer srv.Close()
+
+			repo, err := NewClient(srv.URL, "token").CreateRepo(context.Background(), tt.org, CreateRepoOptions{Name: "api", Private: true})
+			if err != nil {
+				t.Fatalf("CreateRepo: %v", err)
+			}
+			if repo.SSHURL != "git@github.com:acme/api.git" {
+				t.Errorf("SSHURL = %q", repo.SSHURL)
+			}
+		})
+	}
+}
+
+func TestCreateRepoRequiresToken(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("no request expected without a token")
+	}))
+	defer srv.Close()
+
+	if _, err := NewClient(srv.URL, "").CreateRepo(context.Background(), "", CreateRepoOptions{Name: "api"}); err == nil {
+		t.Fatal("CreateRepo succeeded without a token")
+	}
+}
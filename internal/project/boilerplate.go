@@ -0,0 +1,127 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/okalexiiis/dwrk/internal/catalog"
+)
+
+// licenseFiles are the names a project's license file is looked up under, in
+// order of preference.
+var licenseFiles = []string{"LICENSE", "LICENSE.md", "LICENSE.txt", "COPYING"}
+
+// LicenseOptions defines how a license is written by SetLicense.
+type LicenseOptions struct {
+	Holder string // Copyright holder (default: license_holder, then the commit author name)
+	Year   int    // Copyright year (default: the current year)
+}
+
+// AddGitignore merges the named catalog templates into the .gitignore of the
+// named project, creating it if needed. Templates already merged are skipped,
+// so running it again changes nothing. It returns the templates that were added.
+func (m *Manager) AddGitignore(name string, templates []string) ([]string, error) {
+	proj, err := m.existingProject(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return mergeGitignore(proj.Path, templates)
+}
+
+// SetLicense writes the license with the given SPDX identifier to the named
+// project, replacing its current license file if it has one, and returns the
+// path written.
+func (m *Manager) SetLicense(name, id string, opts LicenseOptions) (string, error) {
+	proj, err := m.existingProject(name)
+	if err != nil {
+		return "", err
+	}
+
+	license, err := catalog.FindLicense(id)
+	if err != nil {
+		return "", err
+	}
+
+	holder := opts.Holder
+	if holder == "" {
+		holder = m.licenseHolder(proj.Path, "")
+	}
+	year := opts.Year
+	if year == 0 {
+		year = time.Now().Year()
+	}
+
+	content, err := license.Render(holder, year)
+	if err != nil {
+		return "", err
+	}
+
+	path, _ := licensePath(proj.Path)
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return "", fmt.Errorf("failed writing %s: %w", filepath.Base(path), err)
+	}
+	return path, nil
+}
+
+// licenseHolder returns the copyright holder for a license in dir: the
+// configured license_holder, the given commit author, Git's user.name in dir,
+// or the GitHub username, whichever is set first.
+func (m *Manager) licenseHolder(dir, author string) string {
+	for _, holder := range []string{m.cfg.LicenseHolder, author, gitConfig(dir, "user.name")} {
+		if holder != "" {
+			return holder
+		}
+	}
+	return m.githubUsername
+}
+
+// licensePath returns the license file of the project in dir and whether it
+// exists; LICENSE is returned for projects without one.
+func licensePath(dir string) (string, bool) {
+	for _, file := range licenseFiles {
+		path := filepath.Join(dir, file)
+		if _, err := os.Stat(path); err == nil {
+			return path, true
+		}
+	}
+	return filepath.Join(dir, licenseFiles[0]), false
+}
+
+// mergeGitignore merges catalog templates into the .gitignore in dir and
+// returns the templates that added something.
+func mergeGitignore(dir string, templates []string) ([]string, error) {
+	path := filepath.Join(dir, ".gitignore")
+
+	existing, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed reading .gitignore: %w", err)
+	}
+
+	content, added, err := catalog.MergeGitignore(existing, templates...)
+	if err != nil {
+		return nil, err
+	}
+	if len(added) == 0 {
+		return nil, nil
+	}
+
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return nil, fmt.Errorf("failed writing .gitignore: %w", err)
+	}
+	return added, nil
+}
+
+// existingProject returns the named project, failing if its directory is gone.
+func (m *Manager) existingProject(name string) (*Project, error) {
+	proj, err := m.GetExact(name)
+	if err != nil {
+		return nil, err
+	}
+	if proj.Missing {
+		return nil, fmt.Errorf("project '%s' is registered at %s but the directory no longer exists", proj.Name, proj.Path)
+	}
+	return proj, nil
+}
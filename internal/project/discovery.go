@@ -0,0 +1,211 @@
+package project
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// DefaultScanDepth is the number of directory levels scanned below each root
+// when the configuration does not set one.
+const DefaultScanDepth = 1
+
+// projectMarkers are entries whose presence identifies a project directory.
+// Discovery never descends past a directory containing one of them.
+var projectMarkers = []string{
+	".git",
+	".dwrk",
+	"go.mod",
+	"package.json",
+	"Cargo.toml",
+	"pyproject.toml",
+	"pom.xml",
+}
+
+// discovered is a project directory found while scanning a root.
+type discovered struct {
+	root string      // Root the project was found under
+	rel  string      // Path relative to root
+	info os.FileInfo // Stat information of the project directory
+}
+
+func (d discovered) path() string {
+	return filepath.Join(d.root, d.rel)
+}
+
+// discover walks root looking for project directories, at most maxDepth levels deep.
+//
+// With a depth of 1 every directory directly under root is a project. Deeper
+// scans treat directories without a project marker as groups (for example
+// <root>/<org>/<repo>) and descend into them, unless they are registered, have
+// no subdirectories, or sit at the maximum depth.
+func discover(root string, maxDepth int, showHidden bool, isRegistered func(string) bool) ([]discovered, error) {
+	if maxDepth < 1 {
+		maxDepth = DefaultScanDepth
+	}
+
+	var found []discovered
+
+	var walk func(dir string, depth int) error
+	walk = func(dir string, depth int) error {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+
+		for _, entry := range entries {
+			if !entry.IsDir() {
+				continue
+			}
+			if !showHidden && strings.HasPrefix(entry.Name(), ".") {
+				continue
+			}
+			// Worktrees are listed through their registry entries.
+			if strings.HasSuffix(entry.Name(), worktreesSuffix) {
+				continue
+			}
+
+			path := filepath.Join(dir, entry.Name())
+
+			if depth < maxDepth && !isRegistered(path) && !hasMarker(path) && hasSubdirs(path) {
+				if err := walk(path, depth+1); err != nil && !os.IsPermission(err) {
+					return err
+				}
+				continue
+			}
+
+			info, err := entry.Info()
+			if err != nil {
+				continue
+			}
+
+			rel, _ := filepath.Rel(root, path)
+			found = append(found, discovered{root: root, rel: rel, info: info})
+		}
+
+		return nil
+	}
+
+	if err := walk(root, 1); err != nil {
+		return nil, err
+	}
+
+	return found, nil
+}
+
+// hasMarker reports whether dir contains any of the project markers.
+func hasMarker(dir string) bool {
+	for _, marker := range projectMarkers {
+		if _, err := os.Stat(filepath.Join(dir, marker)); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// hasSubdirs reports whether dir contains at least one non-hidden directory.
+func hasSubdirs(dir string) bool {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return false
+	}
+	for _, entry := range entries {
+		if entry.IsDir() && !strings.HasPrefix(entry.Name(), ".") {
+			return true
+		}
+	}
+	return false
+}
+
+// rootSeparator joins a root label and the project path within that root in
+// the names of projects that need qualifying. Unlike '/', it is accepted by
+// validateProjectName, so qualified names can be registered.
+const rootSeparator = "~"
+
+// rootLabels returns a short, stable label for every root: its base name, or
+// as many of its trailing path elements as needed to tell it apart from the
+// other roots, joined with '-' (e.g. "work-src" and "oss-src").
+func rootLabels(roots []string) map[string]string {
+	parts := make([][]string, len(roots))
+	levels := make([]int, len(roots))
+	for i, root := range roots {
+		parts[i] = strings.FieldsFunc(filepath.ToSlash(root), func(r rune) bool {
+			return r == '/' || r == ':'
+		})
+		levels[i] = 1
+	}
+
+	label := func(i int) string {
+		p := parts[i]
+		return strings.Join(p[max(len(p)-levels[i], 0):], "-")
+	}
+
+	for changed := true; changed; {
+		changed = false
+
+		counts := map[string]int{}
+		for i := range roots {
+			counts[label(i)]++
+		}
+		for i := range roots {
+			if counts[label(i)] > 1 && levels[i] < len(parts[i]) {
+				levels[i]++
+				changed = true
+			}
+		}
+	}
+
+	labels := make(map[string]string, len(roots))
+	for i, root := range roots {
+		labels[root] = label(i)
+	}
+	return labels
+}
+
+// disambiguate assigns a display name to every discovered project.
+//
+// Projects are named after their directory. When that name is already taken
+// by another discovered project or by a registry entry, it is qualified with
+// the label of its root, as <root>~<name>. Projects sharing a name within the
+// same root use their whole path in it instead, as <root>~<org>~<name>.
+func disambiguate(found []discovered, taken map[string]bool, labels map[string]string) []string {
+	candidates := func(d discovered) []string {
+		rel := strings.Split(filepath.ToSlash(d.rel), "/")
+		base := rel[len(rel)-1]
+		return []string{
+			base,
+			labels[d.root] + rootSeparator + base,
+			labels[d.root] + rootSeparator + strings.Join(rel, rootSeparator),
+		}
+	}
+
+	levels := make([]int, len(found))
+	name := func(i int) string {
+		return candidates(found[i])[levels[i]]
+	}
+
+	for changed := true; changed; {
+		changed = false
+
+		counts := map[string]int{}
+		for i := range found {
+			counts[name(i)]++
+		}
+
+		for i := range found {
+			if n := name(i); counts[n] == 1 && !taken[n] {
+				continue
+			}
+			if levels[i] < 2 {
+				levels[i]++
+				changed = true
+			}
+		}
+	}
+
+	names := make([]string, len(found))
+	for i := range found {
+		names[i] = name(i)
+	}
+	return names
+}
@@ -0,0 +1,159 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/okalexiiis/dwrk/internal/catalog"
+)
+
+// gitInitOptions is the resolved setup of a new project's repository.
+type gitInitOptions struct {
+	branch      string
+	gitignore   []string
+	license     *catalog.License
+	holder      string // Copyright holder written in the license
+	authorName  string // Commit identity stored in the repository config
+	authorEmail string
+	noCommit    bool
+}
+
+// resolveGitInit merges the repository options of a project with the
+// configured defaults and checks them, so nothing is created when a license
+// or .gitignore template does not exist.
+func (m *Manager) resolveGitInit(opts CreateOptions) (*gitInitOptions, error) {
+	setup := &gitInitOptions{
+		branch:    opts.Branch,
+		gitignore: opts.Gitignore,
+		noCommit:  opts.NoCommit || m.cfg.SkipInitialCommit,
+	}
+	if setup.branch == "" {
+		setup.branch = m.cfg.InitBranch
+	}
+	if setup.gitignore == nil {
+		setup.gitignore = m.cfg.InitGitignore
+	}
+	if _, err := catalog.Gitignore(setup.gitignore...); err != nil {
+		return nil, err
+	}
+
+	var err error
+	setup.authorName, setup.authorEmail, err = m.cfg.Identity(opts.Host)
+	if err != nil {
+		return nil, err
+	}
+
+	licenseID := opts.License
+	if licenseID == "" {
+		licenseID = m.cfg.InitLicense
+	}
+	if licenseID != "" {
+		if setup.license, err = catalog.FindLicense(licenseID); err != nil {
+			return nil, err
+		}
+		setup.holder = m.licenseHolder("", setup.authorName)
+	}
+
+	return setup, nil
+}
+
+// initGitRepo initializes a Git repository, adds a README.md and LICENSE
+// unless the project already has them, merges the .gitignore templates into
+// any existing .gitignore, and commits everything.
+//
+// When no commit email is configured anywhere, the repository is left without
+// a commit instead of failing.
+func initGitRepo(projectPath, projectName string, opts *gitInitOptions) error {
+	args := []string{"init", "--quiet"}
+	if opts.branch != "" {
+		args = append(args, "--initial-branch", opts.branch)
+	}
+	if err := gitIn(projectPath, args...); err != nil {
+		return err
+	}
+
+	if opts.authorName != "" {
+		if err := gitIn(projectPath, "config", "user.name", opts.authorName); err != nil {
+			return err
+		}
+	}
+	if opts.authorEmail != "" {
+		if err := gitIn(projectPath, "config", "user.email", opts.authorEmail); err != nil {
+			return err
+		}
+	}
+
+	readme := fmt.Sprintf("# %s\n\nProject initialized using Project Manager CLI.\n", projectName)
+	if err := writeIfMissing(filepath.Join(projectPath, "README.md"), []byte(readme)); err != nil {
+		return err
+	}
+
+	if _, err := mergeGitignore(projectPath, opts.gitignore); err != nil {
+		return err
+	}
+
+	if path, exists := licensePath(projectPath); opts.license != nil && !exists {
+		content, err := opts.license.Render(opts.holder, time.Now().Year())
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			return fmt.Errorf("failed creating LICENSE: %w", err)
+		}
+	}
+
+	if opts.noCommit {
+		return nil
+	}
+	if gitConfig(projectPath, "user.email") == "" {
+		fmt.Fprintln(os.Stderr, "Warning: no Git user.email configured, skipping the initial commit")
+		fmt.Fprintln(os.Stderr, "Set one with: dwrk config set git_user_email <email>")
+		return nil
+	}
+
+	if err := gitIn(projectPath, "add", "--all"); err != nil {
+		return err
+	}
+	return gitIn(projectPath, "commit", "--quiet", "-m", "Initial commit")
+}
+
+// gitIn runs a git command in dir, including Git's output in the error.
+func gitIn(dir string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		if msg := strings.TrimSpace(string(out)); msg != "" {
+			return fmt.Errorf("failed running 'git %s': %s", args[0], msg)
+		}
+		return fmt.Errorf("failed running 'git %s': %w", args[0], err)
+	}
+	return nil
+}
+
+// gitConfig returns a Git configuration value as seen from dir (the current
+// directory if empty), or an empty string if it is not set.
+func gitConfig(dir, key string) string {
+	cmd := exec.Command("git", "config", "--get", key)
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// writeIfMissing writes a file unless it already exists, e.g. because the
+// project template provides its own.
+func writeIfMissing(path string, content []byte) error {
+	if _, err := os.Stat(path); err == nil {
+		return nil
+	}
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		return fmt.Errorf("failed creating %s: %w", filepath.Base(path), err)
+	}
+	return nil
+}
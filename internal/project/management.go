@@ -1,32 +1,78 @@
 package project
 
 import (
+	"errors"
 	"fmt"
 	"os"
-	"os/exec"
 	"path/filepath"
+	"slices"
+	"sort"
 	"strings"
 	"time"
 
+	"github.com/okalexiiis/dwrk/internal/config"
+	"github.com/okalexiiis/dwrk/internal/github"
+	"github.com/okalexiiis/dwrk/internal/templates"
 	"github.com/okalexiiis/dwrk/pkg/utils"
 )
 
 // Manager handles project discovery, creation, and metadata retrieval.
 type Manager struct {
-	baseDir string
+	roots          []string // Directories scanned for projects; new projects go in the first one
+	scanDepth      int      // Maximum directory depth scanned below each root
+	templatesDir   string   // Directory where project templates are stored
+	githubUsername string   // Used to build the module path of rendered templates
+	useSSH         bool     // Fetch Git-hosted templates and push new repositories over SSH
+
+	cfg          *config.Config // Repository defaults and commit identities of new projects
+	github       *github.Client
+	registryPath string
+	registry     *Registry
 }
 
 // ListOptions defines filtering options for the List method.
 type ListOptions struct {
-	ShowHidden bool   // Include directories starting with a dot
-	Filter     string // Substring filter applied to project names
-	Search     string // Future extension for fuzzy search or advanced matching
+	ShowHidden bool     // Include directories starting with a dot
+	Filter     string   // Substring filter applied to project names
+	Search     string   // Fuzzy query; matching projects are returned best match first
+	Tags       []string // Only projects carrying every one of these tags
 }
 
 // CreateOptions defines optional behaviors when creating a project.
 type CreateOptions struct {
-	InitGit  bool   // Initialize a Git repository after creating the folder
-	Template string // Name of the template to use
+	InitGit  bool              // Initialize a Git repository after creating the folder
+	Template string            // Name of the template to use
+	Vars     map[string]string // Variables available to the template as {{.Vars.key}}
+
+	// Interactive prompts on stdin for template variables that were not
+	// provided. Otherwise defaults are used and missing required ones fail.
+	Interactive bool
+
+	NoHooks bool // Skip the template's post_create commands
+
+	// Remote creates a GitHub repository with this visibility ("public" or
+	// "private"), adds it as origin and pushes the initial commit. It
+	// requires InitGit.
+	Remote    string
+	RemoteOrg string // Organization owning the repository (default: the authenticated user)
+
+	// Repository setup when InitGit is set; empty values fall back to the
+	// init_* settings of the configuration.
+	Branch    string   // Initial branch name
+	Gitignore []string // .gitignore templates from the catalog
+	License   string   // SPDX identifier of the license added as LICENSE
+	Host      string   // Host whose commit identity is used (default: default_host)
+	NoCommit  bool     // Leave the repository without an initial commit
+}
+
+// RegisterOptions defines the metadata recorded when registering a project.
+type RegisterOptions struct {
+	Type           string          // Free-form project kind
+	Template       string          // Template the project was created from
+	TemplateSource *TemplateSource // Exact template version the project was created from
+	Settings       map[string]any  // Arbitrary per-project settings
+	Tags           []string        // Labels used to select groups of projects; nil keeps the current ones, empty clears them
+	WorktreeOf     *WorktreeOf     // Set when registering a worktree as project@branch; kept on later registrations
 }
 
 // Project describes a project discovered or created by the Manager.
@@ -35,76 +81,224 @@ type Project struct {
 	Path    string
 	IsGit   bool
 	LastMod time.Time
+
+	// Metadata coming from the registry.
+	Type           string
+	Template       string
+	TemplateSource *TemplateSource
+	WorktreeOf     *WorktreeOf // Set for worktrees registered as project@branch
+	CreatedAt      time.Time
+	Settings       map[string]any
+	Tags           []string
+	Registered     bool // The project has an entry in the registry
+	Missing        bool // The registered path no longer exists on disk
+}
+
+// NewManager creates a new Manager using the project roots and scan depth
+// from the given configuration. Project metadata is read from and written to
+// the default registry file.
+func NewManager(cfg *config.Config) *Manager {
+	return &Manager{
+		roots:          cfg.ProjectRoots(),
+		scanDepth:      cfg.ScanDepth,
+		templatesDir:   utils.ExpandPath(cfg.TemplatesDir),
+		githubUsername: cfg.GitHubUsername,
+		useSSH:         cfg.UseSSH,
+		cfg:            cfg,
+		github:         github.NewClientFromConfig(cfg),
+		registryPath:   RegistryPath(),
+	}
+}
+
+// ErrNoProjectsDir is returned when the configuration names no directory
+// where projects can be created.
+var ErrNoProjectsDir = errors.New("no projects directory configured, set projects_dir")
+
+// ProjectsDir returns the directory where new projects are created.
+func (m *Manager) ProjectsDir() (string, error) {
+	if len(m.roots) == 0 {
+		return "", ErrNoProjectsDir
+	}
+	return m.roots[0], nil
+}
+
+// Roots returns every directory scanned for projects.
+func (m *Manager) Roots() []string {
+	return m.roots
 }
 
-// NewManager creates a new Manager using the provided base directory.
-func NewManager(baseDir string) *Manager {
-	return &Manager{baseDir: baseDir}
+// Registry returns the project registry, loading it from disk on first use.
+func (m *Manager) Registry() (*Registry, error) {
+	if m.registry != nil {
+		return m.registry, nil
+	}
+
+	reg, err := LoadRegistry(m.registryPath)
+	if err != nil {
+		return nil, err
+	}
+	m.registry = reg
+	return reg, nil
 }
 
-// List returns all projects under the base directory, applying the given filters.
+// List returns all projects found under the configured roots merged with the
+// projects recorded in the registry, applying the given filters.
 //
-// A project is any non-hidden directory unless ShowHidden is enabled.
-// Git repositories are automatically detected.
+// Hidden directories are skipped unless ShowHidden is enabled. Git repositories
+// are automatically detected. Registered projects whose directory has
+// disappeared are returned with Missing set.
 func (m *Manager) List(opts ListOptions) ([]Project, error) {
-	entries, err := os.ReadDir(m.baseDir)
+	reg, err := m.Registry()
 	if err != nil {
 		return nil, err
 	}
 
-	var projects []Project
+	isRegistered := func(path string) bool {
+		_, _, ok := reg.FindByPath(path)
+		return ok
+	}
 
-	for _, entry := range entries {
-		if !entry.IsDir() {
-			continue
+	var found []discovered
+	for _, root := range m.roots {
+		rootProjects, err := discover(root, m.scanDepth, opts.ShowHidden, isRegistered)
+		if err != nil && !os.IsNotExist(err) {
+			return nil, err
 		}
+		found = append(found, rootProjects...)
+	}
+
+	var projects []Project
+	var unregistered []discovered
+	seen := map[string]bool{}
 
-		name := entry.Name()
+	for _, d := range found {
+		projectPath := d.path()
+		seen[projectPath] = true
 
-		if !opts.ShowHidden && strings.HasPrefix(name, ".") {
+		// A registered directory is listed under its registered name.
+		regName, regEntry, registered := reg.FindByPath(projectPath)
+		if !registered {
+			unregistered = append(unregistered, d)
 			continue
 		}
 
-		if opts.Filter != "" &&
-			!strings.Contains(strings.ToLower(name), strings.ToLower(opts.Filter)) {
-			continue
+		proj := Project{
+			Name:    regName,
+			Path:    projectPath,
+			IsGit:   isGitRepo(projectPath),
+			LastMod: d.info.ModTime(),
 		}
+		applyEntry(&proj, regEntry)
+		projects = append(projects, proj)
+	}
 
-		projectPath := filepath.Join(m.baseDir, name)
-		info, _ := entry.Info()
+	taken := map[string]bool{}
+	for _, name := range reg.Names() {
+		taken[name] = true
+	}
 
+	names := disambiguate(unregistered, taken, rootLabels(m.roots))
+	for i, d := range unregistered {
 		projects = append(projects, Project{
-			Name:    name,
-			Path:    projectPath,
-			IsGit:   isGitRepo(projectPath),
-			LastMod: info.ModTime(),
+			Name:    names[i],
+			Path:    d.path(),
+			IsGit:   isGitRepo(d.path()),
+			LastMod: d.info.ModTime(),
 		})
 	}
 
-	return projects, nil
+	for _, name := range reg.Names() {
+		regEntry, _ := reg.Get(name)
+		if seen[filepath.Clean(regEntry.Path)] {
+			continue
+		}
+		projects = append(projects, projectFromEntry(name, regEntry))
+	}
+
+	filtered := projects[:0]
+	for _, proj := range projects {
+		if matchesFilter(proj.Name, opts.Filter) && hasTags(proj.Tags, opts.Tags) {
+			filtered = append(filtered, proj)
+		}
+	}
+
+	return Search(filtered, opts.Search), nil
+}
+
+// Search returns the projects whose name fuzzily matches query, best match
+// first; ties go to the shorter name. With an empty query every project is
+// returned, sorted by name. The given slice is not modified.
+func Search(projects []Project, query string) []Project {
+	matches := make([]Project, 0, len(projects))
+	scores := map[string]int{}
+	for _, proj := range projects {
+		if query != "" {
+			score, ok := utils.FuzzyScore(query, proj.Name)
+			if !ok {
+				continue
+			}
+			scores[proj.Name] = score
+		}
+		matches = append(matches, proj)
+	}
+
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if scores[a.Name] != scores[b.Name] {
+			return scores[a.Name] > scores[b.Name]
+		}
+		if query != "" && len(a.Name) != len(b.Name) {
+			return len(a.Name) < len(b.Name)
+		}
+		return a.Name < b.Name
+	})
+
+	return matches
 }
 
 // Create creates a new project directory and optionally initializes a Git repository.
 //
 // The name is validated to avoid invalid or unsafe folder names.
 // If Git initialization fails, the created directory is removed to maintain consistency.
+// Once a remote repository has been created the directory is always kept, and
+// errors name the repository so it is not forgotten on GitHub.
 func (m *Manager) Create(name string, opts CreateOptions) (*Project, error) {
 	if err := validateProjectName(name); err != nil {
 		return nil, err
 	}
+	if err := m.validateRemote(opts); err != nil {
+		return nil, err
+	}
+	var gitInit *gitInitOptions
+	if opts.InitGit {
+		var err error
+		if gitInit, err = m.resolveGitInit(opts); err != nil {
+			return nil, err
+		}
+	}
 
+	projectsDir, err := m.ProjectsDir()
+	if err != nil {
+		return nil, err
+	}
+	projectPath := filepath.Join(projectsDir, name)
+
+	if _, err := os.Stat(projectPath); err == nil {
+		return nil, fmt.Errorf("directory already exists: %s", projectPath)
+	}
 	if m.Exists(name) {
 		return nil, fmt.Errorf("a project named '%s' already exists", name)
 	}
-
-	projectPath := filepath.Join(m.baseDir, name)
 	if err := os.MkdirAll(projectPath, 0755); err != nil {
 		return nil, fmt.Errorf("failed to create directory: %w", err)
 	}
 
-	// 2. Aplicar Plantilla (si se especificó)
+	// Aplicar Plantilla (si se especificó)
+	var templateSource *TemplateSource
 	if opts.Template != "" {
-		if err := m.applyTemplate(projectPath, name, opts.Template); err != nil {
+		var err error
+		templateSource, err = m.applyTemplate(projectPath, name, opts)
+		if err != nil {
 			// Es crucial limpiar si la aplicación de la plantilla falla
 			os.RemoveAll(projectPath)
 			return nil, fmt.Errorf("failed to apply template '%s': %w", opts.Template, err)
@@ -113,56 +307,321 @@ func (m *Manager) Create(name string, opts CreateOptions) (*Project, error) {
 
 	isGit := false
 	if opts.InitGit {
-		if err := initGitRepo(projectPath, name); err != nil {
+		if err := initGitRepo(projectPath, name, gitInit); err != nil {
 			os.RemoveAll(projectPath)
 			return nil, fmt.Errorf("failed to initialize git: %w", err)
 		}
 		isGit = true
 	}
 
-	return &Project{
-		Name:    name,
-		Path:    projectPath,
-		IsGit:   isGit,
-		LastMod: time.Now(),
-	}, nil
+	var remoteRepo *github.Repository
+	if opts.Remote != "" {
+		if remoteRepo, err = m.createRemote(projectPath, name, opts); err != nil {
+			os.RemoveAll(projectPath)
+			return nil, fmt.Errorf("failed to create remote repository: %w", err)
+		}
+	}
+
+	proj, err := m.Register(name, projectPath, RegisterOptions{
+		Template:       opts.Template,
+		TemplateSource: templateSource,
+	})
+	if err != nil {
+		if remoteRepo != nil {
+			// The GitHub repository already exists; removing the local copy
+			// would leave it orphaned.
+			return nil, fmt.Errorf("%w (the project was kept at %s, its repository is %s)", err, projectPath, remoteRepo.HTMLURL)
+		}
+		os.RemoveAll(projectPath)
+		return nil, err
+	}
+	proj.IsGit = isGit
+
+	return proj, nil
 }
 
-// Exists checks whether a project with the given name exists.
-func (m *Manager) Exists(name string) bool {
-	projectPath := filepath.Join(m.baseDir, name)
-	info, err := os.Stat(projectPath)
+// Register records an existing directory in the registry under the given name.
+//
+// Registering the same path again under the same name refreshes its metadata.
+// It fails if the name is already used by a different path.
+func (m *Manager) Register(name, path string, opts RegisterOptions) (*Project, error) {
+	if !filepath.IsAbs(path) {
+		return nil, fmt.Errorf("cannot register '%s': path must be absolute", path)
+	}
+	path = filepath.Clean(path)
+
+	reg, err := m.Registry()
+	if err != nil {
+		return nil, err
+	}
+
+	// Worktrees are registered as project@branch, and branches may contain '/'.
+	validate := validateProjectName
+	if strings.Contains(name, WorktreeSeparator) && isWorktree(reg, name, path, opts) {
+		validate = validateWorktreeName
+	}
+	if err := validate(name); err != nil {
+		return nil, err
+	}
+
+	info, err := os.Stat(path)
 	if err != nil {
-		return false
+		return nil, fmt.Errorf("cannot register '%s': %w", path, err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("cannot register '%s': not a directory", path)
+	}
+
+	entry := &Entry{
+		Path:      path,
+		Type:      opts.Type,
+		Template:  opts.Template,
+		CreatedAt: time.Now(),
+		Settings:  opts.Settings,
+		Tags:      opts.Tags,
+
+		TemplateSource: opts.TemplateSource,
+		WorktreeOf:     opts.WorktreeOf,
 	}
-	return info.IsDir()
+
+	previous, ok := reg.Get(name)
+	if ok && filepath.Clean(previous.Path) != path {
+		return nil, fmt.Errorf("a project named '%s' is already registered at %s", name, previous.Path)
+	}
+
+	// Registering a known path under a new name renames its entry.
+	oldName, oldEntry, renamed := reg.FindByPath(path)
+	renamed = renamed && oldName != name
+	if !ok && renamed {
+		previous, ok = oldEntry, true
+	}
+
+	if ok {
+		// Keep the original creation date and any metadata not provided again.
+		entry.CreatedAt = previous.CreatedAt
+		if entry.Type == "" {
+			entry.Type = previous.Type
+		}
+		if entry.Template == "" {
+			entry.Template = previous.Template
+			entry.TemplateSource = previous.TemplateSource
+		}
+		if entry.Settings == nil {
+			entry.Settings = previous.Settings
+		}
+		if entry.Tags == nil {
+			entry.Tags = previous.Tags
+		}
+		if entry.WorktreeOf == nil {
+			entry.WorktreeOf = previous.WorktreeOf
+		}
+	}
+
+	if renamed {
+		reg.Remove(oldName)
+	}
+
+	reg.Set(name, entry)
+	if err := reg.Save(); err != nil {
+		return nil, err
+	}
+
+	proj := projectFromEntry(name, entry)
+	return &proj, nil
+}
+
+// isWorktree reports whether a registration concerns a worktree: one is being
+// registered, or the name or path already belong to one.
+func isWorktree(reg *Registry, name, path string, opts RegisterOptions) bool {
+	if opts.WorktreeOf != nil {
+		return true
+	}
+	if entry, ok := reg.Get(name); ok && entry.WorktreeOf != nil {
+		return true
+	}
+	_, entry, ok := reg.FindByPath(path)
+	return ok && entry.WorktreeOf != nil
+}
+
+// Exists checks whether the name is taken: registered, or used by a
+// directory directly under one of the roots. Unlike Get, it only considers
+// exact names, and it does not scan the roots, so it stays cheap when called
+// for every project of a batch.
+func (m *Manager) Exists(name string) bool {
+	reg, err := m.Registry()
+	if err == nil {
+		if _, ok := reg.Get(name); ok {
+			return true
+		}
+	}
+
+	for _, root := range m.roots {
+		if _, err := os.Stat(filepath.Join(root, name)); err == nil {
+			return true
+		}
+	}
+	return false
 }
 
 // Get retrieves metadata about a specific project by name.
+//
+// Registered projects take precedence over discovered directories. A
+// registered project whose path has disappeared is returned with Missing set.
+// Besides exact names, a project can be referred to by the trailing part of
+// its path (e.g. "repo" or "org/repo"); if several projects match, an
+// *AmbiguousError listing them is returned.
 func (m *Manager) Get(name string) (*Project, error) {
-	if !m.Exists(name) {
-		return nil, fmt.Errorf("project '%s' not found", name)
+	return m.get(name, true)
+}
+
+// GetExact is like Get but only accepts the exact name of a project, never
+// the trailing part of its path. Commands that write to or delete from a
+// project use it, so they cannot act on a project the user did not name.
+func (m *Manager) GetExact(name string) (*Project, error) {
+	return m.get(name, false)
+}
+
+// get implements Get and GetExact.
+func (m *Manager) get(name string, bySuffix bool) (*Project, error) {
+	reg, err := m.Registry()
+	if err != nil {
+		return nil, err
 	}
 
-	projectPath := filepath.Join(m.baseDir, name)
-	info, err := os.Stat(projectPath)
+	if entry, ok := reg.Get(name); ok {
+		proj := projectFromEntry(name, entry)
+		return &proj, nil
+	}
+
+	projects, err := m.List(ListOptions{ShowHidden: true})
 	if err != nil {
 		return nil, err
 	}
 
-	return &Project{
-		Name:    name,
-		Path:    projectPath,
-		IsGit:   isGitRepo(projectPath),
-		LastMod: info.ModTime(),
-	}, nil
+	var candidates []Project
+	for _, proj := range projects {
+		if proj.Name == name {
+			return &proj, nil
+		}
+		if bySuffix && strings.HasSuffix(filepath.ToSlash(proj.Path), "/"+name) {
+			candidates = append(candidates, proj)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, fmt.Errorf("project '%s' %w", name, ErrNotFound)
+	case 1:
+		return &candidates[0], nil
+	default:
+		return nil, &AmbiguousError{Name: name, Candidates: candidates}
+	}
 }
 
-// isGitRepo returns true if the given path contains a .git folder.
+// Find looks up a project named by the user. It behaves like Get, and when
+// no project has that name it falls back to a fuzzy match, so "apisrv" finds
+// "api-server". If several projects match fuzzily, an *AmbiguousError with
+// the candidates ranked best first is returned.
+//
+// Find is meant for lookups whose result is shown to the user before anything
+// happens, such as dwrk open. Commands that write to or delete from a project
+// use GetExact, so a mistyped name cannot act on an unrelated project.
+func (m *Manager) Find(query string) (*Project, error) {
+	proj, err := m.Get(query)
+	if !errors.Is(err, ErrNotFound) {
+		return proj, err
+	}
+
+	matches, listErr := m.List(ListOptions{ShowHidden: true, Search: query})
+	if listErr != nil {
+		return nil, listErr
+	}
+
+	switch len(matches) {
+	case 0:
+		return nil, err
+	case 1:
+		return &matches[0], nil
+	default:
+		return nil, &AmbiguousError{Name: query, Candidates: matches}
+	}
+}
+
+// ErrNotFound is returned (wrapped) when no project has the requested name.
+var ErrNotFound = errors.New("not found")
+
+// AmbiguousError is returned when a name matches more than one project.
+type AmbiguousError struct {
+	Name       string
+	Candidates []Project
+}
+
+func (e *AmbiguousError) Error() string {
+	const shown = 10
+
+	var names []string
+	for i, proj := range e.Candidates {
+		if i == shown {
+			names = append(names, fmt.Sprintf("and %d more", len(e.Candidates)-shown))
+			break
+		}
+		names = append(names, proj.Name)
+	}
+	return fmt.Sprintf("project name '%s' is ambiguous, did you mean one of: %s", e.Name, strings.Join(names, ", "))
+}
+
+// projectFromEntry builds a Project from a registry entry, checking the disk
+// for its current state.
+func projectFromEntry(name string, entry *Entry) Project {
+	proj := Project{
+		Name: name,
+		Path: entry.Path,
+	}
+	applyEntry(&proj, entry)
+
+	info, err := os.Stat(entry.Path)
+	if err != nil || !info.IsDir() {
+		proj.Missing = true
+		return proj
+	}
+
+	proj.IsGit = isGitRepo(entry.Path)
+	proj.LastMod = info.ModTime()
+	return proj
+}
+
+// applyEntry copies registry metadata into a Project.
+func applyEntry(proj *Project, entry *Entry) {
+	proj.Type = entry.Type
+	proj.Template = entry.Template
+	proj.TemplateSource = entry.TemplateSource
+	proj.WorktreeOf = entry.WorktreeOf
+	proj.CreatedAt = entry.CreatedAt
+	proj.Settings = entry.Settings
+	proj.Tags = entry.Tags
+	proj.Registered = true
+}
+
+// matchesFilter reports whether name contains filter, ignoring case.
+func matchesFilter(name, filter string) bool {
+	return filter == "" || strings.Contains(strings.ToLower(name), strings.ToLower(filter))
+}
+
+// hasTags reports whether tags contains every wanted tag.
+func hasTags(tags, wanted []string) bool {
+	for _, want := range wanted {
+		if !slices.Contains(tags, want) {
+			return false
+		}
+	}
+	return true
+}
+
+// isGitRepo returns true if the given path contains a .git folder, or a .git
+// file as found in worktrees and submodules.
 func isGitRepo(path string) bool {
-	gitPath := filepath.Join(path, ".git")
-	info, err := os.Stat(gitPath)
-	return err == nil && info.IsDir()
+	_, err := os.Stat(filepath.Join(path, ".git"))
+	return err == nil
 }
 
 // validateProjectName ensures the project name is safe and valid for use as a directory name.
@@ -185,71 +644,51 @@ func validateProjectName(name string) error {
 	return nil
 }
 
-// initGitRepo initializes a Git repository and generates a README.md file.
+// applyTemplate resolves the specified template, either from the templates
+// directory or from a Git repository, and renders its contents into the
+// destination path. The exact template version and the variables used are
+// returned so they can be recorded.
 //
-// It runs `git init`, creates a default README, adds it to the repository,
-// and performs an initial commit.
-func initGitRepo(projectPath, projectName string) error {
-	cmd := exec.Command("git", "init")
-	cmd.Dir = projectPath
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed running 'git init': %w", err)
-	}
-
-	// Generate README.md
-	readmePath := filepath.Join(projectPath, "README.md")
-	readmeContent := fmt.Sprintf("# %s\n\nProject initialized using Project Manager CLI.\n", projectName)
-
-	if err := os.WriteFile(readmePath, []byte(readmeContent), 0644); err != nil {
-		return fmt.Errorf("failed creating README.md: %w", err)
-	}
-
-	cmd = exec.Command("git", "add", "README.md")
-	cmd.Dir = projectPath
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed running 'git add': %w", err)
+// Variables declared in the template manifest are resolved first, prompting
+// for missing values when running interactively. Every text file, file name
+// and directory name is then processed with text/template; binary files are
+// copied verbatim. Finally the manifest's post_create commands are run in the
+// new project unless NoHooks is set.
+func (m *Manager) applyTemplate(destPath string, projectName string, opts CreateOptions) (*TemplateSource, error) {
+	tmpl, err := templates.NewResolver(m.templatesDir, m.useSSH).Resolve(opts.Template)
+	if err != nil {
+		return nil, err
 	}
+	manifest := tmpl.Manifest
 
-	cmd = exec.Command("git", "commit", "-m", "Initial commit")
-	cmd.Dir = projectPath
-	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("failed running 'git commit': %w", err)
+	vars, err := manifest.Resolve(opts.Vars, templates.ResolveOptions{
+		Interactive: opts.Interactive,
+		In:          os.Stdin,
+		Out:         os.Stdout,
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
-}
-
-// applyTemplate locates the specified template, copies its contents to the destination
-// path, and optionally processes any variables within the template files.
-func (m *Manager) applyTemplate(destPath string, projectName string, templateName string) error {
-	// 1. Get the base path for all templates.
-	// NOTE: This TEMPLATES_BASE_DIR must be replaced with the actual location retrieved
-	// from your application's global configuration (e.g., cfg.TemplatesDir).
-	TEMPLATES_BASE_DIR := "/path/to/dwrk/templates" // Replace with the actual path from config
-
-	templatePath := filepath.Join(TEMPLATES_BASE_DIR, templateName)
+	fmt.Printf("Rendering template '%s' from %s into %s\n", tmpl.Name, tmpl.Path, destPath)
 
-	// 2. Verify that the template directory exists.
-	if _, err := os.Stat(templatePath); os.IsNotExist(err) {
-		return fmt.Errorf("template not found: '%s' (looked in %s)", templateName, templatePath)
-	} else if err != nil {
-		// Handles permissions issues or other file access errors
-		return fmt.Errorf("error checking template directory: %w", err)
+	data := templates.NewData(projectName, m.githubUsername, vars)
+	if err := templates.Render(tmpl.Path, destPath, data); err != nil {
+		return nil, fmt.Errorf("error rendering template files: %w", err)
 	}
 
-	fmt.Printf("Copying template '%s' content from %s to %s\n", templateName, templatePath, destPath)
-
-	// 3. Recursively copy the content from the template directory to the new project path.
-	// The CopyDir function must be implemented and available in the utils package.
-	if err := utils.CopyDir(templatePath, destPath); err != nil {
-		return fmt.Errorf("error copying template files: %w", err)
+	if !opts.NoHooks && len(manifest.PostCreate) > 0 {
+		fmt.Printf("Running %d post_create hook(s)\n", len(manifest.PostCreate))
+		if err := templates.RunHooks(destPath, manifest.PostCreate, data, os.Stdout, os.Stderr); err != nil {
+			return nil, err
+		}
 	}
 
-	// 4. (Optional but Recommended) Process Variables / Template Engine Execution
-	// If you use a templating engine (like Go's text/template), this is where you would
-	// iterate over the copied files and execute the template logic, replacing placeholders
-	// like {{.ProjectName}} with the actual value (projectName).
-	// This step requires additional file traversal and template parsing logic.
-
-	return nil
+	return &TemplateSource{
+		Source: tmpl.Source,
+		Ref:    tmpl.Ref,
+		Commit: tmpl.Commit,
+		Vars:   vars,
+		Name:   projectName,
+	}, nil
 }
@@ -0,0 +1,143 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/okalexiiis/dwrk/internal/config"
+	"github.com/okalexiiis/dwrk/pkg/utils"
+	"gopkg.in/yaml.v3"
+)
+
+// RegistryFileName is the filename of the project registry, stored next to config.yaml.
+const RegistryFileName = "projects.yaml"
+
+// Entry describes a project recorded in the registry.
+type Entry struct {
+	Path      string         `yaml:"path"`                 // Absolute path of the project directory.
+	Type      string         `yaml:"type,omitempty"`       // Free-form kind (standalone, monorepo, ...).
+	Template  string         `yaml:"template,omitempty"`   // Template the project was created from.
+	CreatedAt time.Time      `yaml:"created_at,omitempty"` // When the project was created or registered.
+	Settings  map[string]any `yaml:"settings,omitempty"`   // Arbitrary per-project settings.
+	Tags      []string       `yaml:"tags,omitempty"`       // Labels used to select groups of projects.
+
+	TemplateSource *TemplateSource `yaml:"template_source,omitempty"` // Exact template version used.
+	WorktreeOf     *WorktreeOf     `yaml:"worktree_of,omitempty"`     // Set when the directory is a Git worktree of another project.
+}
+
+// WorktreeOf identifies the project and branch a worktree entry was created for.
+type WorktreeOf struct {
+	Project string `yaml:"project"` // Name of the project owning the repository.
+	Branch  string `yaml:"branch"`  // Branch checked out in the worktree.
+}
+
+// TemplateSource records which version of a template a project was generated
+// from, and with which variables, so it can be rendered again later.
+type TemplateSource struct {
+	Source string            `yaml:"source"`           // Template name or <repo>[//<subdir>] spec.
+	Ref    string            `yaml:"ref,omitempty"`    // Requested branch, tag or commit.
+	Commit string            `yaml:"commit,omitempty"` // Commit the template was rendered from.
+	Vars   map[string]string `yaml:"vars,omitempty"`   // Variable values used when rendering.
+	Name   string            `yaml:"name,omitempty"`   // Project name the template was rendered with.
+}
+
+// Registry is the persistent index of projects known to dwrk.
+//
+// It complements directory discovery: metadata such as the template or the
+// creation date only survives between runs if it is recorded here.
+type Registry struct {
+	Projects map[string]*Entry `yaml:"projects"`
+
+	path string
+}
+
+// RegistryPath returns the absolute path of the registry file.
+func RegistryPath() string {
+	return filepath.Join(filepath.Dir(config.GetConfigPath()), RegistryFileName)
+}
+
+// LoadRegistry reads the registry stored at path.
+// A missing file is not an error: an empty registry is returned instead.
+func LoadRegistry(path string) (*Registry, error) {
+	reg := &Registry{Projects: map[string]*Entry{}, path: path}
+
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return reg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read project registry: %w", err)
+	}
+
+	if err := yaml.Unmarshal(data, reg); err != nil {
+		return nil, fmt.Errorf("failed to parse project registry: %w", err)
+	}
+
+	if reg.Projects == nil {
+		reg.Projects = map[string]*Entry{}
+	}
+
+	// Paths may be edited by hand, so '~/' is accepted.
+	for _, entry := range reg.Projects {
+		entry.Path = utils.ExpandPath(entry.Path)
+	}
+
+	return reg, nil
+}
+
+// Save writes the registry to disk, creating its directory if needed.
+func (r *Registry) Save() error {
+	if err := os.MkdirAll(filepath.Dir(r.path), 0755); err != nil {
+		return fmt.Errorf("failed to create registry directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(r)
+	if err != nil {
+		return fmt.Errorf("failed to serialize project registry: %w", err)
+	}
+
+	if err := os.WriteFile(r.path, data, 0644); err != nil {
+		return fmt.Errorf("failed to write project registry: %w", err)
+	}
+
+	return nil
+}
+
+// Get returns the entry registered under name.
+func (r *Registry) Get(name string) (*Entry, bool) {
+	entry, ok := r.Projects[name]
+	return entry, ok
+}
+
+// Set records or replaces the entry registered under name.
+func (r *Registry) Set(name string, entry *Entry) {
+	r.Projects[name] = entry
+}
+
+// Remove deletes the entry registered under name, if any.
+func (r *Registry) Remove(name string) {
+	delete(r.Projects, name)
+}
+
+// FindByPath returns the name and entry registered for the given directory.
+func (r *Registry) FindByPath(path string) (string, *Entry, bool) {
+	for name, entry := range r.Projects {
+		if filepath.Clean(entry.Path) == filepath.Clean(path) {
+			return name, entry, true
+		}
+	}
+	return "", nil, false
+}
+
+// Names returns the registered project names in alphabetical order.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.Projects))
+	for name := range r.Projects {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
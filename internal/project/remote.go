@@ -0,0 +1,88 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/okalexiiis/dwrk/internal/github"
+)
+
+// Repository visibilities accepted by CreateOptions.Remote.
+const (
+	VisibilityPublic  = "public"
+	VisibilityPrivate = "private"
+)
+
+// validateRemote checks the remote options before anything is created, so a
+// bad flag or missing token does not leave a half-created project behind.
+func (m *Manager) validateRemote(opts CreateOptions) error {
+	if opts.Remote == "" {
+		if opts.RemoteOrg != "" {
+			return fmt.Errorf("an organization can only be given together with a remote")
+		}
+		return nil
+	}
+
+	if opts.Remote != VisibilityPublic && opts.Remote != VisibilityPrivate {
+		return fmt.Errorf("invalid remote visibility '%s' (expected %s or %s)", opts.Remote, VisibilityPublic, VisibilityPrivate)
+	}
+	if !opts.InitGit {
+		return fmt.Errorf("creating a remote repository requires initializing Git")
+	}
+	if !m.github.HasToken() {
+		return fmt.Errorf("creating a remote repository requires a GitHub token (set github_token or %s)", github.TokenEnvVar)
+	}
+
+	return nil
+}
+
+// createRemote creates the GitHub repository of a new project, adds it as
+// origin and pushes the initial commit, if there is one.
+//
+// Once the repository exists on GitHub, failing to push is only reported: the
+// local project is kept so the push can be retried by hand. The created
+// repository is returned whenever it exists.
+func (m *Manager) createRemote(projectPath, name string, opts CreateOptions) (*github.Repository, error) {
+	owner := opts.RemoteOrg
+	if owner == "" {
+		owner = m.githubUsername
+	}
+	fmt.Printf("Creating %s repository %s/%s...\n", opts.Remote, owner, name)
+
+	repo, err := m.github.CreateRepo(context.Background(), opts.RemoteOrg, github.CreateRepoOptions{
+		Name:    name,
+		Private: opts.Remote == VisibilityPrivate,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	remoteURL := repo.CloneURL
+	if m.useSSH && repo.SSHURL != "" {
+		remoteURL = repo.SSHURL
+	}
+
+	if err := exec.Command("git", "-C", projectPath, "remote", "add", "origin", remoteURL).Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: repository created at %s but adding origin failed: %v\n", repo.HTMLURL, err)
+		return repo, nil
+	}
+
+	if exec.Command("git", "-C", projectPath, "rev-parse", "--verify", "--quiet", "HEAD").Run() != nil {
+		fmt.Printf("Repository created at %s; push it after the first commit with: git push -u origin HEAD\n", repo.HTMLURL)
+		return repo, nil
+	}
+
+	push := exec.Command("git", "-C", projectPath, "push", "-u", "origin", "HEAD")
+	push.Stdout = os.Stdout
+	push.Stderr = os.Stderr
+	if err := push.Run(); err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: repository created at %s but the initial push failed: %v\n", repo.HTMLURL, err)
+		fmt.Fprintln(os.Stderr, "Push it later with: git push -u origin HEAD")
+		return repo, nil
+	}
+
+	fmt.Printf("Pushed to %s\n", remoteURL)
+	return repo, nil
+}
@@ -0,0 +1,196 @@
+package project
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/okalexiiis/dwrk/internal/templates"
+)
+
+// UpdateTemplateOptions defines how a project is brought up to date with its template.
+type UpdateTemplateOptions struct {
+	Ref         string            // Template ref to update to; defaults to the recorded one
+	Vars        map[string]string // Variable overrides, also used for newly declared variables
+	Interactive bool              // Prompt for variables the new version declares
+	Force       bool              // Update even if the project has uncommitted changes
+}
+
+// TemplateUpdate describes the outcome of UpdateTemplate.
+type TemplateUpdate struct {
+	*templates.MergeResult
+	FromCommit string
+	ToCommit   string
+}
+
+// UpdateTemplate re-renders the template a project was generated from at a
+// newer version and merges the changes into the project.
+//
+// The recorded template version is rendered again with the recorded
+// variables to obtain the common ancestor; the new version is rendered with
+// the same variables, and both are three-way merged with the project using
+// git. Conflicting hunks are left with conflict markers. The registry is
+// updated with the new template version.
+func (m *Manager) UpdateTemplate(name string, opts UpdateTemplateOptions) (*TemplateUpdate, error) {
+	proj, err := m.GetExact(name)
+	if err != nil {
+		return nil, err
+	}
+	if proj.Missing {
+		return nil, fmt.Errorf("project '%s' is registered at %s but the directory no longer exists", proj.Name, proj.Path)
+	}
+
+	recorded := proj.TemplateSource
+	if recorded == nil || recorded.Commit == "" {
+		return nil, fmt.Errorf("project '%s' has no recorded template version; only projects created from Git-hosted or Git-tracked templates can be updated", proj.Name)
+	}
+
+	if !opts.Force && proj.IsGit && isDirty(proj.Path) {
+		return nil, fmt.Errorf("project '%s' has uncommitted changes; commit or stash them first (or use --force)", proj.Name)
+	}
+
+	baseSpec, newSpec, newRef := m.updateSpecs(recorded, opts.Ref)
+
+	if local := filepath.Join(m.templatesDir, recorded.Source); !templates.IsRemote(recorded.Source) && isDirty(local) {
+		fmt.Fprintf(os.Stderr, "Warning: template '%s' has uncommitted changes; only committed changes are applied\n", recorded.Source)
+	}
+
+	resolver := templates.NewResolver(m.templatesDir, m.useSSH)
+
+	baseTmpl, err := resolver.Resolve(baseSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the original template version: %w", err)
+	}
+	newTmpl, err := resolver.Resolve(newSpec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the new template version: %w", err)
+	}
+
+	baseVars, err := baseTmpl.Manifest.Resolve(recorded.Vars, templates.ResolveOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render the original template version: %w", err)
+	}
+
+	provided := make(map[string]string, len(recorded.Vars)+len(opts.Vars))
+	for key, value := range recorded.Vars {
+		provided[key] = value
+	}
+	for key, value := range opts.Vars {
+		provided[key] = value
+	}
+
+	newVars, err := newTmpl.Manifest.Resolve(provided, templates.ResolveOptions{
+		Interactive: opts.Interactive,
+		In:          os.Stdin,
+		Out:         os.Stdout,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	tmpDir, err := os.MkdirTemp("", "dwrk-template-update-")
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temporary directory: %w", err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	baseDir := filepath.Join(tmpDir, "base")
+	newDir := filepath.Join(tmpDir, "new")
+
+	// Render with the name the project was generated with, which differs from
+	// its current name after a rename or when registered under an alias.
+	renderName := recorded.Name
+	if renderName == "" {
+		renderName = proj.Name
+	}
+
+	if err := templates.Render(baseTmpl.Path, baseDir, m.templateData(proj, renderName, baseVars)); err != nil {
+		return nil, fmt.Errorf("failed to render the original template version: %w", err)
+	}
+	if err := templates.Render(newTmpl.Path, newDir, m.templateData(proj, renderName, newVars)); err != nil {
+		return nil, fmt.Errorf("failed to render the new template version: %w", err)
+	}
+
+	result, err := templates.Merge(proj.Path, baseDir, newDir)
+	if err != nil {
+		return nil, err
+	}
+
+	template := recorded.Source
+	if newRef != "" {
+		template += "@" + newRef
+	}
+
+	_, err = m.Register(proj.Name, proj.Path, RegisterOptions{
+		Template: template,
+		TemplateSource: &TemplateSource{
+			Source: recorded.Source,
+			Ref:    newRef,
+			Commit: newTmpl.Commit,
+			Vars:   newVars,
+			Name:   renderName,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &TemplateUpdate{
+		MergeResult: result,
+		FromCommit:  recorded.Commit,
+		ToCommit:    newTmpl.Commit,
+	}, nil
+}
+
+// updateSpecs returns the template specs of the recorded version and of the
+// version to update to, along with the ref the project will track.
+//
+// Templates stored in the templates directory are addressed through a file://
+// URL so that a specific commit of their Git history can be checked out. Their
+// latest version is their HEAD commit, not the working tree, so the render
+// matches the commit recorded for it.
+func (m *Manager) updateSpecs(recorded *TemplateSource, ref string) (baseSpec, newSpec, newRef string) {
+	repo := recorded.Source
+	if !templates.IsRemote(repo) {
+		repo = "file://" + filepath.Join(m.templatesDir, recorded.Source)
+	}
+
+	newRef = recorded.Ref
+	if ref != "" {
+		newRef = ref
+	}
+
+	baseSpec = repo + "@" + recorded.Commit
+
+	newSpec = repo
+	if newRef != "" {
+		newSpec += "@" + newRef
+	}
+
+	return baseSpec, newSpec, newRef
+}
+
+// templateData builds the rendering context of an existing project, using the
+// name it was generated with. The date fields keep the project's creation
+// date so they do not show up as changes.
+func (m *Manager) templateData(proj *Project, name string, vars map[string]string) templates.Data {
+	data := templates.NewData(name, m.githubUsername, vars)
+
+	created := proj.CreatedAt
+	if created.IsZero() {
+		created = time.Now()
+	}
+	data.Date = created.Format("2006-01-02")
+	data.Year = created.Year()
+
+	return data
+}
+
+// isDirty reports whether the Git repository at path has uncommitted changes.
+func isDirty(path string) bool {
+	out, err := exec.Command("git", "-C", path, "status", "--porcelain").Output()
+	return err != nil || strings.TrimSpace(string(out)) != ""
+}
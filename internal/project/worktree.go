@@ -0,0 +1,316 @@
+package project
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/okalexiiis/dwrk/internal/git"
+	"github.com/okalexiiis/dwrk/pkg/utils"
+)
+
+// WorktreeSeparator joins a project and a branch in the name a worktree is
+// registered under, e.g. "api@fix/login".
+const WorktreeSeparator = "@"
+
+// worktreesSuffix is appended to a project's directory name to form the
+// sibling directory that holds its worktrees.
+const worktreesSuffix = ".worktrees"
+
+// Worktree is a Git worktree of a project.
+type Worktree struct {
+	Name     string // Registered name (project@branch); empty if not registered
+	Branch   string // Checked out branch; empty on a detached HEAD
+	Path     string
+	Commit   string
+	Prunable bool // The directory is gone
+}
+
+// WorktreeName returns the name a worktree of project on branch is
+// registered under.
+func WorktreeName(project, branch string) string {
+	return project + WorktreeSeparator + branch
+}
+
+// WorktreePath returns the directory used for a worktree of proj on branch:
+// <project>.worktrees/<branch>, next to the project directory.
+func WorktreePath(proj *Project, branch string) string {
+	return filepath.Join(worktreesRoot(proj), filepath.FromSlash(branch))
+}
+
+// AddWorktree checks out branch of the named project in a new worktree and
+// registers it as project@branch. A branch that does not exist locally or on
+// a remote is created from base (the project's HEAD if empty).
+func (m *Manager) AddWorktree(ctx context.Context, name, branch, base string) (*Project, error) {
+	proj, err := m.worktreeParent(name)
+	if err != nil {
+		return nil, err
+	}
+	if branch == "" {
+		return nil, fmt.Errorf("branch name cannot be empty")
+	}
+
+	wtName := WorktreeName(proj.Name, branch)
+	if m.Exists(wtName) {
+		return nil, fmt.Errorf("a project named '%s' already exists", wtName)
+	}
+
+	path := WorktreePath(proj, branch)
+	if _, err := os.Stat(path); err == nil {
+		return nil, fmt.Errorf("directory already exists: %s", path)
+	}
+
+	// Stale metadata would make Git refuse a branch whose worktree was deleted by hand.
+	if err := git.PruneWorktrees(ctx, proj.Path); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create directory: %w", err)
+	}
+	if err := git.AddWorktree(ctx, proj.Path, path, branch, base); err != nil {
+		removeEmptyParents(filepath.Dir(path), worktreesRoot(proj))
+		return nil, fmt.Errorf("failed to add worktree: %w", err)
+	}
+
+	return m.Register(wtName, path, RegisterOptions{
+		Type:       "worktree",
+		WorktreeOf: &WorktreeOf{Project: proj.Name, Branch: branch},
+	})
+}
+
+// Worktrees lists the worktrees of the named project, other than the project
+// directory itself, with the names they are registered under.
+func (m *Manager) Worktrees(ctx context.Context, name string) ([]Worktree, error) {
+	proj, err := m.worktreeParent(name)
+	if err != nil {
+		return nil, err
+	}
+
+	gitWorktrees, err := git.Worktrees(ctx, proj.Path)
+	if err != nil {
+		return nil, err
+	}
+
+	reg, err := m.Registry()
+	if err != nil {
+		return nil, err
+	}
+
+	var worktrees []Worktree
+	for _, gwt := range gitWorktrees {
+		if gwt.Main {
+			continue
+		}
+		wt := Worktree{
+			Branch:   gwt.Branch,
+			Path:     gwt.Path,
+			Commit:   gwt.Commit,
+			Prunable: gwt.Prunable,
+		}
+		if regName, _, ok := reg.FindByPath(gwt.Path); ok {
+			wt.Name = regName
+		}
+		worktrees = append(worktrees, wt)
+	}
+
+	return worktrees, nil
+}
+
+// RemoveWorktree removes a worktree of the named project, unregisters it and
+// prunes stale worktree metadata. The worktree is given by the branch it has
+// checked out, its path, or (for detached worktrees) a prefix of its commit.
+// The branch itself is kept. With force, uncommitted changes in the worktree
+// are lost.
+func (m *Manager) RemoveWorktree(ctx context.Context, name, target string, force bool) (*Worktree, error) {
+	proj, err := m.worktreeParent(name)
+	if err != nil {
+		return nil, err
+	}
+
+	worktrees, err := m.Worktrees(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+
+	wt, err := findWorktree(worktrees, target)
+	if err != nil {
+		return nil, fmt.Errorf("project '%s': %w", proj.Name, err)
+	}
+
+	if !wt.Prunable {
+		if err := git.RemoveWorktree(ctx, proj.Path, wt.Path, force); err != nil {
+			return nil, err
+		}
+	}
+	if err := git.PruneWorktrees(ctx, proj.Path); err != nil {
+		return nil, err
+	}
+	removeEmptyParents(filepath.Dir(wt.Path), worktreesRoot(proj))
+
+	err = m.unregisterWorktrees(func(e *Entry) bool {
+		return filepath.Clean(e.Path) == filepath.Clean(wt.Path)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return wt, nil
+}
+
+// findWorktree returns the worktree with target checked out, located at
+// target, or whose commit starts with target (at least 4 characters).
+func findWorktree(worktrees []Worktree, target string) (*Worktree, error) {
+	for i := range worktrees {
+		if worktrees[i].Branch == target {
+			return &worktrees[i], nil
+		}
+	}
+
+	if path, err := filepath.Abs(utils.ExpandPath(target)); err == nil {
+		for i := range worktrees {
+			if filepath.Clean(worktrees[i].Path) == path {
+				return &worktrees[i], nil
+			}
+		}
+	}
+
+	var found *Worktree
+	if len(target) >= 4 {
+		for i := range worktrees {
+			if !strings.HasPrefix(worktrees[i].Commit, target) {
+				continue
+			}
+			if found != nil {
+				return nil, fmt.Errorf("commit '%s' matches several worktrees; use the path instead", target)
+			}
+			found = &worktrees[i]
+		}
+	}
+	if found == nil {
+		return nil, fmt.Errorf("no worktree with branch, path or commit '%s'", target)
+	}
+	return found, nil
+}
+
+// PruneWorktrees runs `git worktree prune` in the named project and
+// unregisters its worktrees whose directory no longer exists. It returns the
+// names that were unregistered.
+func (m *Manager) PruneWorktrees(ctx context.Context, name string) ([]string, error) {
+	proj, err := m.worktreeParent(name)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := git.PruneWorktrees(ctx, proj.Path); err != nil {
+		return nil, err
+	}
+
+	reg, err := m.Registry()
+	if err != nil {
+		return nil, err
+	}
+
+	var pruned []string
+	for _, regName := range reg.Names() {
+		entry, _ := reg.Get(regName)
+		if entry.WorktreeOf == nil || entry.WorktreeOf.Project != proj.Name {
+			continue
+		}
+		if _, err := os.Stat(entry.Path); os.IsNotExist(err) {
+			pruned = append(pruned, regName)
+			removeEmptyParents(filepath.Dir(entry.Path), worktreesRoot(proj))
+		}
+	}
+
+	for _, regName := range pruned {
+		reg.Remove(regName)
+	}
+	if len(pruned) > 0 {
+		if err := reg.Save(); err != nil {
+			return nil, err
+		}
+	}
+
+	return pruned, nil
+}
+
+// validateWorktreeName checks a project@branch name. The project part follows
+// the rules of validateProjectName; the branch may contain '/' but none of the
+// characters Git forbids in branch names.
+func validateWorktreeName(name string) error {
+	project, branch, ok := strings.Cut(name, WorktreeSeparator)
+	if !ok {
+		return fmt.Errorf("worktree name '%s' must have the form project%sbranch", name, WorktreeSeparator)
+	}
+	if err := validateProjectName(project); err != nil {
+		return err
+	}
+	if branch == "" || strings.HasPrefix(branch, "/") || strings.HasSuffix(branch, "/") ||
+		strings.Contains(branch, "..") || strings.Contains(branch, "//") || strings.ContainsAny(branch, `\:*?"<>| ~^[`) {
+		return fmt.Errorf("invalid branch in worktree name: %s", name)
+	}
+	return nil
+}
+
+// worktreeParent returns the named project if it can hold worktrees.
+func (m *Manager) worktreeParent(name string) (*Project, error) {
+	proj, err := m.GetExact(name)
+	if err != nil {
+		return nil, err
+	}
+	if proj.Missing {
+		return nil, fmt.Errorf("project '%s' is registered at %s but the directory no longer exists", proj.Name, proj.Path)
+	}
+	if proj.WorktreeOf != nil {
+		return nil, fmt.Errorf("'%s' is a worktree of '%s'; use the project instead", proj.Name, proj.WorktreeOf.Project)
+	}
+	if !proj.IsGit {
+		return nil, fmt.Errorf("project '%s' is not a Git repository", proj.Name)
+	}
+	return proj, nil
+}
+
+// unregisterWorktrees removes the worktree entries matching fn from the registry.
+func (m *Manager) unregisterWorktrees(fn func(*Entry) bool) error {
+	reg, err := m.Registry()
+	if err != nil {
+		return err
+	}
+
+	removed := false
+	for _, regName := range reg.Names() {
+		entry, _ := reg.Get(regName)
+		if entry.WorktreeOf != nil && fn(entry) {
+			reg.Remove(regName)
+			removed = true
+		}
+	}
+
+	if !removed {
+		return nil
+	}
+	return reg.Save()
+}
+
+// worktreesRoot returns the directory holding the worktrees of proj.
+func worktreesRoot(proj *Project) string {
+	return filepath.Join(filepath.Dir(proj.Path), filepath.Base(proj.Path)+worktreesSuffix)
+}
+
+// removeEmptyParents removes dir and its parents up to and including root,
+// stopping at the first one that is not empty. Branch names with slashes
+// leave nested directories behind.
+func removeEmptyParents(dir, root string) {
+	for {
+		rel, err := filepath.Rel(root, dir)
+		if err != nil || strings.HasPrefix(rel, "..") {
+			return
+		}
+		if os.Remove(dir) != nil || rel == "." {
+			return
+		}
+		dir = filepath.Dir(dir)
+	}
+}
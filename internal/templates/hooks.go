@@ -0,0 +1,34 @@
+package templates
+
+import (
+	"fmt"
+	"io"
+	"os/exec"
+)
+
+// RunHooks executes the post_create commands of a template in dir, in order.
+//
+// Each command is rendered with the template data, run through `sh -c` and
+// has its output streamed to stdout/stderr. The first failing command stops
+// the sequence and its error is returned.
+func RunHooks(dir string, commands []string, data Data, stdout, stderr io.Writer) error {
+	for i, command := range commands {
+		rendered, err := RenderString(fmt.Sprintf("post_create[%d]", i), command, data)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(stdout, "→ %s\n", rendered)
+
+		cmd := exec.Command("sh", "-c", rendered)
+		cmd.Dir = dir
+		cmd.Stdout = stdout
+		cmd.Stderr = stderr
+
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("post_create hook '%s' failed: %w", rendered, err)
+		}
+	}
+
+	return nil
+}
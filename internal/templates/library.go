@@ -0,0 +1,199 @@
+package templates
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/okalexiiis/dwrk/internal/git"
+	"github.com/okalexiiis/dwrk/pkg/utils"
+)
+
+// Template is a project template stored in the templates directory.
+type Template struct {
+	Name     string
+	Path     string
+	Manifest *Manifest
+	Source   string // Git remote the template was cloned from, or "local"
+}
+
+// List returns every template found in templatesDir, in alphabetical order.
+// Templates with an unreadable manifest are still listed, with an empty one.
+func List(templatesDir string) ([]Template, error) {
+	entries, err := os.ReadDir(templatesDir)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read templates directory: %w", err)
+	}
+
+	var result []Template
+	for _, entry := range entries {
+		if !entry.IsDir() || strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+
+		path := filepath.Join(templatesDir, entry.Name())
+		manifest, err := LoadManifest(path)
+		if err != nil {
+			manifest = &Manifest{}
+		}
+
+		result = append(result, Template{
+			Name:     entry.Name(),
+			Path:     path,
+			Manifest: manifest,
+			Source:   source(path),
+		})
+	}
+
+	return result, nil
+}
+
+// Get returns the template named name from templatesDir.
+func Get(templatesDir, name string) (*Template, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+
+	path := filepath.Join(templatesDir, name)
+
+	info, err := os.Stat(path)
+	if os.IsNotExist(err) {
+		return nil, fmt.Errorf("template not found: '%s' (looked in %s)", name, path)
+	} else if err != nil {
+		return nil, fmt.Errorf("error checking template directory: %w", err)
+	}
+	if !info.IsDir() {
+		return nil, fmt.Errorf("template '%s' is not a directory", name)
+	}
+
+	manifest, err := LoadManifest(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Template{
+		Name:     name,
+		Path:     path,
+		Manifest: manifest,
+		Source:   source(path),
+	}, nil
+}
+
+// Add stores a new template named name in templatesDir.
+//
+// If from is an existing directory, its contents are copied as a snapshot.
+// Otherwise from is treated as a Git URL and cloned.
+func Add(templatesDir, name, from string) (*Template, error) {
+	if err := validateName(name); err != nil {
+		return nil, err
+	}
+
+	dest := filepath.Join(templatesDir, name)
+	if _, err := os.Stat(dest); err == nil {
+		return nil, fmt.Errorf("a template named '%s' already exists", name)
+	}
+
+	if err := os.MkdirAll(templatesDir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create templates directory: %w", err)
+	}
+
+	if info, err := os.Stat(utils.ExpandPath(from)); err == nil && info.IsDir() {
+		if err := utils.CopyDir(utils.ExpandPath(from), dest); err != nil {
+			os.RemoveAll(dest)
+			return nil, fmt.Errorf("failed to copy template: %w", err)
+		}
+		return Get(templatesDir, name)
+	}
+
+	_, err := git.NewCloner().Clone(context.Background(), from, dest, git.CloneOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to clone template: %w", err)
+	}
+
+	return Get(templatesDir, name)
+}
+
+// Remove deletes the template named name from templatesDir.
+func Remove(templatesDir, name string) error {
+	if err := validateName(name); err != nil {
+		return err
+	}
+
+	tmpl, err := Get(templatesDir, name)
+	if err != nil {
+		return err
+	}
+
+	if err := os.RemoveAll(tmpl.Path); err != nil {
+		return fmt.Errorf("failed to remove template: %w", err)
+	}
+
+	return nil
+}
+
+// Files returns the paths, relative to the template root, of every file and
+// directory that is copied into new projects.
+func (t *Template) Files() ([]string, error) {
+	var files []string
+
+	err := filepath.WalkDir(t.Path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, _ := filepath.Rel(t.Path, path)
+		if rel == "." || rel == ManifestFileName {
+			return nil
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		if d.IsDir() {
+			rel += string(filepath.Separator)
+		}
+		files = append(files, rel)
+		return nil
+	})
+
+	return files, err
+}
+
+// Description returns the manifest description, if any.
+func (t *Template) Description() string {
+	if t.Manifest == nil {
+		return ""
+	}
+	return t.Manifest.Description
+}
+
+// source returns the origin remote of a template that is a Git checkout.
+func source(path string) string {
+	if !utils.IsGitRepo(path) {
+		return "local"
+	}
+
+	out, err := exec.Command("git", "-C", path, "remote", "get-url", "origin").Output()
+	if err != nil {
+		return "local"
+	}
+	return strings.TrimSpace(string(out))
+}
+
+// validateName ensures a template name is usable as a directory name.
+func validateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("template name cannot be empty")
+	}
+	if strings.ContainsAny(name, `/\:*?"<>|`) || strings.HasPrefix(name, ".") {
+		return fmt.Errorf("invalid template name: %s", name)
+	}
+	return nil
+}
@@ -0,0 +1,294 @@
+package templates
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ManifestFileName is the file at the template root that declares its inputs.
+// It is never copied into generated projects.
+const ManifestFileName = "template.yaml"
+
+// Variable types supported in a manifest.
+const (
+	TypeString = "string"
+	TypeBool   = "bool"
+	TypeChoice = "choice"
+)
+
+// Manifest describes a template and the variables it expects.
+type Manifest struct {
+	Name        string     `yaml:"name,omitempty"`
+	Description string     `yaml:"description,omitempty"`
+	Variables   []Variable `yaml:"variables,omitempty"`
+	PostCreate  []string   `yaml:"post_create,omitempty"` // Commands run in the new project, in order
+
+	// Verbatim lists glob patterns of files copied without rendering their
+	// contents, for files that contain '{{' of their own (GitHub Actions
+	// workflows, Helm charts, ...). A pattern without '/' matches the name of
+	// any file or directory; one with '/' matches a path from the template
+	// root. Matching a directory covers everything inside it.
+	Verbatim []string `yaml:"verbatim,omitempty"`
+}
+
+// Variable is a single input declared by a template.
+type Variable struct {
+	Name        string   `yaml:"name"`
+	Description string   `yaml:"description,omitempty"`
+	Type        string   `yaml:"type,omitempty"`     // string (default), bool or choice
+	Default     string   `yaml:"default,omitempty"`  // Value used when none is provided
+	Choices     []string `yaml:"choices,omitempty"`  // Allowed values for choice variables
+	Validate    string   `yaml:"validate,omitempty"` // Regular expression the value must match
+	Required    bool     `yaml:"required,omitempty"` // Fail if no value or default is available
+}
+
+// ResolveOptions controls how missing variable values are obtained.
+type ResolveOptions struct {
+	Interactive bool      // Prompt for values that were not provided
+	In          io.Reader // Source of answers when interactive
+	Out         io.Writer // Destination of prompts when interactive
+}
+
+// LoadManifest reads the manifest at the root of templateDir.
+// Templates without a manifest get an empty one.
+func LoadManifest(templateDir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(templateDir, ManifestFileName))
+	if os.IsNotExist(err) {
+		return &Manifest{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", ManifestFileName, err)
+	}
+
+	manifest := &Manifest{}
+	if err := yaml.Unmarshal(data, manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ManifestFileName, err)
+	}
+
+	if err := manifest.validate(); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", ManifestFileName, err)
+	}
+
+	return manifest, nil
+}
+
+// validate checks that the manifest declarations are consistent.
+func (m *Manifest) validate() error {
+	for _, pattern := range m.Verbatim {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid verbatim pattern '%s': %w", pattern, err)
+		}
+	}
+
+	seen := map[string]bool{}
+
+	for i := range m.Variables {
+		v := &m.Variables[i]
+
+		if v.Name == "" {
+			return fmt.Errorf("variable #%d has no name", i+1)
+		}
+		if seen[v.Name] {
+			return fmt.Errorf("variable '%s' is declared twice", v.Name)
+		}
+		seen[v.Name] = true
+
+		if v.Type == "" {
+			v.Type = TypeString
+		}
+
+		switch v.Type {
+		case TypeString, TypeBool:
+		case TypeChoice:
+			if len(v.Choices) == 0 {
+				return fmt.Errorf("choice variable '%s' declares no choices", v.Name)
+			}
+		default:
+			return fmt.Errorf("variable '%s' has unknown type '%s'", v.Name, v.Type)
+		}
+
+		if v.Validate != "" {
+			if _, err := regexp.Compile(v.Validate); err != nil {
+				return fmt.Errorf("variable '%s' has an invalid validate pattern: %w", v.Name, err)
+			}
+		}
+
+		if v.Default != "" {
+			if _, err := v.Check(v.Default); err != nil {
+				return fmt.Errorf("default of variable '%s' is invalid: %w", v.Name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Resolve computes the final value of every declared variable.
+//
+// Provided values are validated and kept. Missing values are prompted for when
+// interactive, otherwise the default is used. A required variable without a
+// value makes the call fail, listing every missing name at once. Provided
+// values that are not declared are passed through unchanged.
+func (m *Manifest) Resolve(provided map[string]string, opts ResolveOptions) (map[string]string, error) {
+	values := make(map[string]string, len(provided))
+	for key, value := range provided {
+		values[key] = value
+	}
+
+	var reader *bufio.Reader
+	if opts.Interactive {
+		reader = bufio.NewReader(opts.In)
+	}
+
+	var missing []string
+
+	for _, v := range m.Variables {
+		if value, ok := provided[v.Name]; ok {
+			normalized, err := v.Check(value)
+			if err != nil {
+				return nil, fmt.Errorf("variable '%s': %w", v.Name, err)
+			}
+			values[v.Name] = normalized
+			continue
+		}
+
+		if opts.Interactive {
+			value, err := v.prompt(reader, opts.Out)
+			if err != nil {
+				return nil, err
+			}
+			values[v.Name] = value
+			continue
+		}
+
+		switch {
+		case v.Default != "":
+			values[v.Name], _ = v.Check(v.Default)
+		case v.Required:
+			missing = append(missing, v.Name)
+		case v.Type == TypeBool:
+			values[v.Name] = "false"
+		default:
+			values[v.Name] = ""
+		}
+	}
+
+	if len(missing) > 0 {
+		return nil, fmt.Errorf("missing required template variable(s): %s (pass them with --var name=value)",
+			strings.Join(missing, ", "))
+	}
+
+	return values, nil
+}
+
+// Check validates value against the variable declaration and returns its
+// normalized form (booleans become "true" or "false").
+func (v Variable) Check(value string) (string, error) {
+	switch v.Type {
+	case TypeBool:
+		switch strings.ToLower(strings.TrimSpace(value)) {
+		case "true", "yes", "y", "1":
+			return "true", nil
+		case "false", "no", "n", "0":
+			return "false", nil
+		default:
+			return "", fmt.Errorf("'%s' is not a boolean (use true/false)", value)
+		}
+
+	case TypeChoice:
+		if !slices.Contains(v.Choices, value) {
+			return "", fmt.Errorf("'%s' is not one of: %s", value, strings.Join(v.Choices, ", "))
+		}
+	}
+
+	if v.Validate != "" {
+		if !regexp.MustCompile(v.Validate).MatchString(value) {
+			return "", fmt.Errorf("'%s' does not match %s", value, v.Validate)
+		}
+	}
+
+	if v.Required && value == "" {
+		return "", fmt.Errorf("a value is required")
+	}
+
+	return value, nil
+}
+
+// prompt asks for the variable value until a valid one is entered.
+// An empty answer selects the default.
+func (v Variable) prompt(reader *bufio.Reader, out io.Writer) (string, error) {
+	label := v.Name
+	if v.Description != "" {
+		label = fmt.Sprintf("%s - %s", v.Name, v.Description)
+	}
+
+	switch v.Type {
+	case TypeBool:
+		label += " (y/n)"
+	case TypeChoice:
+		label += fmt.Sprintf(" (%s)", strings.Join(v.Choices, "/"))
+	}
+
+	if v.Default != "" {
+		label += fmt.Sprintf(" [%s]", v.Default)
+	}
+
+	for {
+		fmt.Fprintf(out, "%s: ", label)
+
+		answer, err := reader.ReadString('\n')
+		if err != nil && answer == "" {
+			return "", fmt.Errorf("no value entered for '%s': %w", v.Name, err)
+		}
+
+		answer = strings.TrimSpace(answer)
+		if answer == "" {
+			answer = v.Default
+		}
+		if answer == "" && v.Type == TypeBool && !v.Required {
+			answer = "false"
+		}
+
+		value, checkErr := v.Check(answer)
+		if checkErr == nil {
+			return value, nil
+		}
+
+		fmt.Fprintf(out, "  invalid value: %v\n", checkErr)
+		if err != nil {
+			// Input ended; there is nothing left to retry with.
+			return "", fmt.Errorf("variable '%s': %w", v.Name, checkErr)
+		}
+	}
+}
+
+// IsVerbatim reports whether the file at rel, relative to the template root,
+// matches one of the verbatim patterns.
+func (m *Manifest) IsVerbatim(rel string) bool {
+	elems := strings.Split(filepath.ToSlash(rel), "/")
+
+	for _, pattern := range m.Verbatim {
+		pattern = strings.Trim(pattern, "/")
+		for i := range elems {
+			var name string
+			if strings.Contains(pattern, "/") {
+				name = strings.Join(elems[:i+1], "/")
+			} else {
+				name = elems[i]
+			}
+			if ok, _ := path.Match(pattern, name); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,218 @@
+package templates
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+)
+
+// ConflictSuffix is appended to the upstream version of a binary file that
+// could not be merged; it is written next to the project's own copy.
+const ConflictSuffix = ".dwrk-new"
+
+// MergeResult summarizes a three-way merge of a template into a project.
+type MergeResult struct {
+	Updated   []string // Files changed upstream and merged cleanly
+	Added     []string // Files introduced by the new template version
+	Deleted   []string // Files removed upstream and unmodified in the project
+	Conflicts []string // Files left with conflict markers (or a .dwrk-new copy)
+	Kept      []string // Files removed upstream but modified in the project
+	Missing   []string // Files changed upstream that the project deleted; they are not restored
+}
+
+// Changed reports whether the merge touched the project at all.
+func (r *MergeResult) Changed() bool {
+	return len(r.Updated)+len(r.Added)+len(r.Deleted)+len(r.Conflicts) > 0
+}
+
+// Merge applies the changes between two renders of a template to a project.
+//
+// baseDir holds the render the project was originally generated from and
+// newDir the render of the newer template version. Files the project did not
+// modify are simply replaced; files modified on both sides are merged with
+// `git merge-file`, leaving conflict markers where changes overlap.
+func Merge(projectDir, baseDir, newDir string) (*MergeResult, error) {
+	baseFiles, err := listFiles(baseDir)
+	if err != nil {
+		return nil, err
+	}
+	newFiles, err := listFiles(newDir)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := map[string]bool{}
+	for rel := range baseFiles {
+		paths[rel] = true
+	}
+	for rel := range newFiles {
+		paths[rel] = true
+	}
+
+	sorted := make([]string, 0, len(paths))
+	for rel := range paths {
+		sorted = append(sorted, rel)
+	}
+	sort.Strings(sorted)
+
+	result := &MergeResult{}
+
+	for _, rel := range sorted {
+		basePath := filepath.Join(baseDir, rel)
+		newPath := filepath.Join(newDir, rel)
+		projectPath := filepath.Join(projectDir, rel)
+
+		base, inBase := readIf(baseFiles[rel], basePath)
+		upstream, inNew := readIf(newFiles[rel], newPath)
+		current, err := os.ReadFile(projectPath)
+		inProject := err == nil
+
+		switch {
+		case inBase && inNew && bytes.Equal(base, upstream):
+			// Unchanged upstream: whatever the project did stays.
+
+		case !inBase && inNew && !inProject:
+			if err := writeLike(projectPath, upstream, newPath); err != nil {
+				return nil, err
+			}
+			result.Added = append(result.Added, rel)
+
+		case inBase && !inNew && !inProject:
+			// Removed on both sides.
+
+		case inBase && !inNew:
+			if bytes.Equal(current, base) {
+				if err := os.Remove(projectPath); err != nil {
+					return nil, err
+				}
+				result.Deleted = append(result.Deleted, rel)
+			} else {
+				result.Kept = append(result.Kept, rel)
+			}
+
+		case inBase && !inProject:
+			// Deleted in the project on purpose; do not bring it back.
+			result.Missing = append(result.Missing, rel)
+
+		case bytes.Equal(current, upstream):
+			// The project already has the new content.
+
+		case inBase && bytes.Equal(current, base):
+			if err := writeLike(projectPath, upstream, newPath); err != nil {
+				return nil, err
+			}
+			result.Updated = append(result.Updated, rel)
+
+		default:
+			// Modified on both sides (or added on both sides with different content).
+			conflict, err := mergeFile(projectPath, base, upstream)
+			if err != nil {
+				return nil, fmt.Errorf("failed to merge %s: %w", rel, err)
+			}
+			if conflict {
+				result.Conflicts = append(result.Conflicts, rel)
+			} else {
+				result.Updated = append(result.Updated, rel)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// mergeFile merges the changes from base to upstream into the file at path.
+// It reports whether conflicts were left in the file.
+func mergeFile(path string, base, upstream []byte) (bool, error) {
+	current, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	if isBinary(current) || isBinary(base) || isBinary(upstream) {
+		return true, os.WriteFile(path+ConflictSuffix, upstream, 0644)
+	}
+
+	tmpDir, err := os.MkdirTemp("", "dwrk-merge-")
+	if err != nil {
+		return false, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	basePath := filepath.Join(tmpDir, "base")
+	upstreamPath := filepath.Join(tmpDir, "template")
+	if err := os.WriteFile(basePath, base, 0644); err != nil {
+		return false, err
+	}
+	if err := os.WriteFile(upstreamPath, upstream, 0644); err != nil {
+		return false, err
+	}
+
+	cmd := exec.Command("git", "merge-file",
+		"-L", "project", "-L", "base", "-L", "template",
+		path, basePath, upstreamPath)
+
+	err = cmd.Run()
+	if err == nil {
+		return false, nil
+	}
+
+	// git merge-file exits with the number of conflicts, or a negative
+	// value (255) when it fails.
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() > 0 && exitErr.ExitCode() < 128 {
+		return true, nil
+	}
+	return false, err
+}
+
+// listFiles returns the regular files under dir, keyed by relative path.
+func listFiles(dir string) (map[string]bool, error) {
+	files := map[string]bool{}
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+		if d.Type().IsRegular() {
+			rel, _ := filepath.Rel(dir, path)
+			files[rel] = true
+		}
+		return nil
+	})
+
+	return files, err
+}
+
+// readIf reads path when present is true.
+func readIf(present bool, path string) ([]byte, bool) {
+	if !present {
+		return nil, false
+	}
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+	return content, true
+}
+
+// writeLike writes content to path with the permissions of the file at like,
+// creating parent directories as needed.
+func writeLike(path string, content []byte, like string) error {
+	perm := os.FileMode(0644)
+	if info, err := os.Stat(like); err == nil {
+		perm = info.Mode().Perm()
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, perm)
+}
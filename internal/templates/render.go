@@ -0,0 +1,192 @@
+package templates
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+	"time"
+	"unicode/utf8"
+)
+
+// binarySniffLen is how many leading bytes are inspected to detect binary files.
+const binarySniffLen = 8000
+
+// Data is the context available to templates while rendering.
+//
+// Fields are referenced from template files as {{.ProjectName}},
+// {{.ModulePath}}, {{.Vars.some_key}}, etc.
+type Data struct {
+	ProjectName    string            // Name of the project being created
+	GitHubUsername string            // GitHub username from the configuration
+	ModulePath     string            // Go module path (github.com/<user>/<project>)
+	Date           string            // Creation date formatted as YYYY-MM-DD
+	Year           int               // Creation year
+	Vars           map[string]string // User-supplied variables (--var key=value)
+}
+
+// NewData builds the rendering context for a project.
+func NewData(projectName, githubUsername string, vars map[string]string) Data {
+	now := time.Now()
+	if vars == nil {
+		vars = map[string]string{}
+	}
+
+	return Data{
+		ProjectName:    projectName,
+		GitHubUsername: githubUsername,
+		ModulePath:     fmt.Sprintf("github.com/%s/%s", githubUsername, projectName),
+		Date:           now.Format("2006-01-02"),
+		Year:           now.Year(),
+		Vars:           vars,
+	}
+}
+
+// Render copies the template directory src into dest, rendering every text
+// file and every file or directory name through text/template.
+//
+// Binary files and files matching the manifest's verbatim patterns are copied
+// as-is, although their names are still rendered. The template manifest and
+// the .git directory of the template, if any, are never copied.
+func Render(src, dest string, data Data) error {
+	manifest, err := LoadManifest(src)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(dest, 0755); err != nil {
+		return fmt.Errorf("create %s: %w", dest, err)
+	}
+
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		if rel == "." || rel == ManifestFileName {
+			return nil
+		}
+
+		if d.IsDir() && d.Name() == ".git" {
+			return filepath.SkipDir
+		}
+
+		renderedRel, err := renderPath(rel, data)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dest, renderedRel)
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case d.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm())
+
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+
+		case info.Mode().IsRegular():
+			return renderFile(path, rel, target, info.Mode().Perm(), data, manifest.IsVerbatim(rel))
+
+		default:
+			// Sockets, devices and the like have no place in a template.
+			return nil
+		}
+	})
+}
+
+// RenderString renders a single template string with the given data.
+// The name is used in error messages to point at the offending template.
+func RenderString(name, text string, data Data) (string, error) {
+	tmpl, err := template.New(name).Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", err
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// renderPath renders each segment of a relative path that contains template actions.
+func renderPath(rel string, data Data) (string, error) {
+	segments := strings.Split(rel, string(filepath.Separator))
+
+	for i, segment := range segments {
+		if !strings.Contains(segment, "{{") {
+			continue
+		}
+
+		rendered, err := RenderString(rel, segment, data)
+		if err != nil {
+			return "", err
+		}
+
+		if rendered == "" || rendered == "." || rendered == ".." || strings.ContainsAny(rendered, `/\`) {
+			return "", fmt.Errorf("invalid file name rendered from %s: %q", rel, rendered)
+		}
+		segments[i] = rendered
+	}
+
+	return filepath.Join(segments...), nil
+}
+
+// renderFile renders a single file into target, copying it verbatim if it is
+// binary or verbatim is set.
+func renderFile(path, rel, target string, perm os.FileMode, data Data, verbatim bool) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read template file: %w", err)
+	}
+
+	if !verbatim && !isBinary(content) {
+		rendered, err := RenderString(rel, string(content), data)
+		if err != nil {
+			return err
+		}
+		content = []byte(rendered)
+	}
+
+	if err := os.WriteFile(target, content, perm); err != nil {
+		return fmt.Errorf("write %s: %w", target, err)
+	}
+
+	return nil
+}
+
+// isBinary reports whether content looks like binary data: it contains a NUL
+// byte or is not valid UTF-8 within its first bytes.
+func isBinary(content []byte) bool {
+	sample := content
+	if len(sample) > binarySniffLen {
+		sample = sample[:binarySniffLen]
+
+		// Drop the last rune so a multi-byte character cut by the sample is not
+		// mistaken for invalid UTF-8.
+		i := len(sample) - 1
+		for i > len(sample)-utf8.UTFMax && !utf8.RuneStart(sample[i]) {
+			i--
+		}
+		sample = sample[:i]
+	}
+
+	return bytes.IndexByte(sample, 0) != -1 || !utf8.Valid(sample)
+}
@@ -0,0 +1,324 @@
+package templates
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/okalexiiis/dwrk/internal/git"
+	"github.com/okalexiiis/dwrk/pkg/utils"
+)
+
+// commitPattern matches full or abbreviated commit hashes.
+var commitPattern = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+// Source is a template hosted in a Git repository.
+//
+// It is written as <repo>[//<subdir>][@<ref>], for example
+// github.com/our-org/templates//go-service@v1.4. The repository may be a bare
+// host/owner/repo path or any URL git understands.
+type Source struct {
+	Repo   string // URL passed to git clone
+	Subdir string // Directory inside the repository holding the template
+	Ref    string // Branch, tag or commit; empty means the default branch
+}
+
+// Resolved is a template ready to be rendered, together with the exact
+// version it was taken from.
+type Resolved struct {
+	*Template
+	Source string // Template spec without the ref, as recorded in the registry
+	Ref    string // Requested ref, if any
+	Commit string // Commit the template files were read from, if known
+}
+
+// Resolver locates templates either in the templates directory or in Git
+// repositories, which are fetched into a local cache.
+type Resolver struct {
+	TemplatesDir string // Directory holding local templates
+	CacheDir     string // Directory where remote templates are checked out
+	UseSSH       bool   // Clone bare host/owner/repo sources over SSH
+
+	cloner *git.Cloner
+}
+
+// NewResolver creates a Resolver using the default cache directory
+// (~/.cache/dwrk/templates on Linux).
+func NewResolver(templatesDir string, useSSH bool) *Resolver {
+	return &Resolver{
+		TemplatesDir: templatesDir,
+		CacheDir:     DefaultCacheDir(),
+		UseSSH:       useSSH,
+		cloner:       git.NewCloner(),
+	}
+}
+
+// DefaultCacheDir returns the directory where remote templates are cached.
+func DefaultCacheDir() string {
+	cacheDir, err := os.UserCacheDir()
+	if err != nil {
+		homeDir, _ := os.UserHomeDir()
+		cacheDir = filepath.Join(homeDir, ".cache")
+	}
+	return filepath.Join(cacheDir, "dwrk", "templates")
+}
+
+// IsRemote reports whether spec refers to a Git-hosted template rather than
+// to a template stored in the templates directory. Local template names
+// never contain path separators or colons.
+func IsRemote(spec string) bool {
+	return strings.ContainsAny(spec, "/:")
+}
+
+// ParseSource parses a <repo>[//<subdir>][@<ref>] template spec.
+// Bare host/owner/repo paths are turned into SSH or HTTPS URLs.
+func ParseSource(spec string, useSSH bool) (*Source, error) {
+	src := &Source{}
+	rest := spec
+
+	// The subdirectory follows a '//' that is not part of the scheme.
+	searchFrom := 0
+	if i := strings.Index(rest, "://"); i != -1 {
+		searchFrom = i + len("://")
+	}
+	subdirAt := -1
+	if i := strings.Index(rest[searchFrom:], "//"); i != -1 {
+		subdirAt = searchFrom + i
+	}
+
+	// The ref is the last '@' past the repository host, or past the '//' when
+	// there is a subdirectory, so refs may contain slashes (release/1.4). An
+	// '@' in the host part belongs to the URL (git@host:owner/repo).
+	refFrom := subdirAt
+	if refFrom == -1 {
+		refFrom = repoPathStart(rest, searchFrom)
+	}
+	if at := strings.LastIndex(rest[refFrom:], "@"); at != -1 {
+		at += refFrom
+		src.Ref = rest[at+1:]
+		rest = rest[:at]
+		if src.Ref == "" {
+			return nil, fmt.Errorf("invalid template source '%s': empty ref", spec)
+		}
+	}
+
+	if subdirAt != -1 {
+		src.Subdir = strings.Trim(rest[subdirAt+2:], "/")
+		rest = rest[:subdirAt]
+	}
+
+	rest = strings.TrimSuffix(rest, "/")
+	if rest == "" {
+		return nil, fmt.Errorf("invalid template source '%s': missing repository", spec)
+	}
+
+	if strings.Contains(src.Subdir, "..") {
+		return nil, fmt.Errorf("invalid template source '%s': subdirectory cannot contain '..'", spec)
+	}
+
+	src.Repo = repoURL(rest, useSSH)
+	return src, nil
+}
+
+// repoPathStart returns the index where the owner/repo path begins in a repo
+// spec whose scheme, if any, ends at from: after the ':' of scp-like URLs
+// (git@host:owner/repo), otherwise after the first '/' following the host.
+func repoPathStart(spec string, from int) int {
+	slash := strings.Index(spec[from:], "/")
+	if from == 0 {
+		if colon := strings.Index(spec, ":"); colon != -1 && (slash == -1 || colon < slash) {
+			return colon + 1
+		}
+	}
+	if slash == -1 {
+		return len(spec)
+	}
+	return from + slash + 1
+}
+
+// repoURL turns a bare host/owner/repo path into a clone URL.
+// Anything that already looks like a URL is returned unchanged.
+func repoURL(repo string, useSSH bool) string {
+	if strings.Contains(repo, "://") || strings.Contains(repo, ":") {
+		return repo
+	}
+
+	host, path, _ := strings.Cut(repo, "/")
+	if !strings.HasSuffix(path, ".git") {
+		path += ".git"
+	}
+
+	if useSSH {
+		return fmt.Sprintf("git@%s:%s", host, path)
+	}
+	return fmt.Sprintf("https://%s/%s", host, path)
+}
+
+// Resolve locates the template referred to by spec.
+//
+// Local template names are looked up in the templates directory. Remote specs
+// are fetched into the cache (or refreshed if already cached) and the
+// template is read from the requested subdirectory.
+func (r *Resolver) Resolve(spec string) (*Resolved, error) {
+	if !IsRemote(spec) {
+		tmpl, err := Get(r.TemplatesDir, spec)
+		if err != nil {
+			return nil, err
+		}
+		resolved := &Resolved{Template: tmpl, Source: spec}
+		if utils.IsGitRepo(tmpl.Path) {
+			resolved.Commit, _ = headCommit(tmpl.Path)
+		}
+		return resolved, nil
+	}
+
+	src, err := ParseSource(spec, r.UseSSH)
+	if err != nil {
+		return nil, err
+	}
+
+	checkout, err := r.fetch(src)
+	if err != nil {
+		return nil, err
+	}
+
+	commit, err := headCommit(checkout)
+	if err != nil {
+		return nil, err
+	}
+
+	tmpl, err := r.load(src, checkout)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Resolved{
+		Template: tmpl,
+		Source:   strings.TrimSuffix(spec, "@"+src.Ref),
+		Ref:      src.Ref,
+		Commit:   commit,
+	}, nil
+}
+
+// load reads the template found in the source subdirectory of a checkout.
+func (r *Resolver) load(src *Source, checkout string) (*Template, error) {
+	path := filepath.Join(checkout, src.Subdir)
+	if info, err := os.Stat(path); err != nil || !info.IsDir() {
+		return nil, fmt.Errorf("template directory '%s' not found in %s", src.Subdir, src.Repo)
+	}
+
+	manifest, err := LoadManifest(path)
+	if err != nil {
+		return nil, err
+	}
+
+	name := filepath.Base(path)
+	if src.Subdir == "" {
+		name = utils.ExtractRepoNameFromURL(src.Repo)
+	}
+
+	return &Template{
+		Name:     name,
+		Path:     path,
+		Manifest: manifest,
+		Source:   src.Repo,
+	}, nil
+}
+
+// fetch makes sure the requested ref of the source repository is checked out
+// in the cache and returns the checkout directory.
+//
+// Cached checkouts are refreshed from the remote; if that fails (for example
+// when offline), the cached copy is used as is.
+func (r *Resolver) fetch(src *Source) (string, error) {
+	dest := filepath.Join(r.CacheDir, cacheKey(src))
+
+	if utils.IsGitRepo(dest) {
+		if err := refresh(dest, src.Ref); err != nil {
+			fmt.Fprintf(os.Stderr, "Warning: could not refresh cached template, using cached copy: %v\n", err)
+		}
+		return dest, nil
+	}
+
+	opts := git.CloneOptions{Quiet: true}
+	if !commitPattern.MatchString(src.Ref) {
+		opts.Branch = src.Ref
+		opts.Depth = 1
+	}
+
+	_, err := r.cloner.Clone(context.Background(), src.Repo, dest, opts)
+	if err == nil && opts.Branch == "" && src.Ref != "" {
+		// Commits cannot be cloned directly: clone the history and check out.
+		err = runGit(dest, "checkout", "--quiet", src.Ref)
+	}
+	if err != nil {
+		os.RemoveAll(dest)
+		return "", fmt.Errorf("failed to fetch template from %s: %w", src.Repo, err)
+	}
+
+	return dest, nil
+}
+
+// refresh updates a cached checkout to the latest commit of ref.
+// Commits are immutable, so a checkout pinned to one is left untouched.
+func refresh(dir, ref string) error {
+	if commitPattern.MatchString(ref) {
+		return nil
+	}
+
+	target := ref
+	if target == "" {
+		target = "HEAD"
+	}
+
+	if err := runGit(dir, "fetch", "--quiet", "--depth", "1", "origin", target); err != nil {
+		return err
+	}
+	return runGit(dir, "reset", "--quiet", "--hard", "FETCH_HEAD")
+}
+
+// cacheKey returns the cache subdirectory of a source: the repository path
+// without scheme or user, followed by @ref.
+func cacheKey(src *Source) string {
+	repo := src.Repo
+	if i := strings.Index(repo, "://"); i != -1 {
+		repo = repo[i+3:]
+	}
+	if i := strings.Index(repo, "@"); i != -1 {
+		repo = repo[i+1:]
+	}
+	repo = strings.TrimSuffix(repo, ".git")
+	repo = strings.NewReplacer(":", "/", "..", "_").Replace(repo)
+
+	// Refs may contain slashes; escape them so each ref gets its own directory.
+	ref := url.PathEscape(src.Ref)
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	return filepath.FromSlash(strings.Trim(repo, "/")) + "@" + ref
+}
+
+// headCommit returns the commit checked out in dir.
+func headCommit(dir string) (string, error) {
+	out, err := exec.Command("git", "-C", dir, "rev-parse", "HEAD").Output()
+	if err != nil {
+		return "", fmt.Errorf("failed to read template commit: %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// runGit runs a git command in dir, returning its stderr on failure.
+func runGit(dir string, args ...string) error {
+	cmd := exec.Command("git", args...)
+	cmd.Dir = dir
+	if out, err := cmd.CombinedOutput(); err != nil {
+		return fmt.Errorf("git %s: %s", args[0], strings.TrimSpace(string(out)))
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package utils
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// clipboardCommands are the programs tried, in order, to write to the system
+// clipboard, with the environment variable that must be set for each to work.
+var clipboardCommands = []struct {
+	args []string
+	env  string
+}{
+	{args: []string{"pbcopy"}},
+	{args: []string{"wl-copy"}, env: "WAYLAND_DISPLAY"},
+	{args: []string{"xclip", "-selection", "clipboard"}, env: "DISPLAY"},
+	{args: []string{"xsel", "--clipboard", "--input"}, env: "DISPLAY"},
+	{args: []string{"clip.exe"}},
+}
+
+// CopyToClipboard writes text to the system clipboard using the first
+// clipboard program found. Without one, and when stdout is a terminal, the
+// text is sent with the OSC 52 escape sequence, which most terminal emulators
+// (and tmux with set-clipboard on) turn into a clipboard write.
+func CopyToClipboard(text string) error {
+	for _, c := range clipboardCommands {
+		if c.env != "" && os.Getenv(c.env) == "" {
+			continue
+		}
+		if _, err := exec.LookPath(c.args[0]); err != nil {
+			continue
+		}
+
+		cmd := exec.Command(c.args[0], c.args[1:]...)
+		cmd.Stdin = strings.NewReader(text)
+		if err := cmd.Run(); err != nil {
+			return fmt.Errorf("%s failed: %w", c.args[0], err)
+		}
+		return nil
+	}
+
+	if !IsTerminal(os.Stdout) {
+		return errors.New("no clipboard program found (pbcopy, wl-copy, xclip or xsel)")
+	}
+	fmt.Printf("\x1b]52;c;%s\a", base64.StdEncoding.EncodeToString([]byte(text)))
+	return nil
+}
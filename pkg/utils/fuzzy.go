@@ -0,0 +1,107 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// Scores used by FuzzyScore. Every matched character is worth scoreMatch;
+// matches at the start of the target, at the start of a word or right after
+// the previous match earn a bonus, and characters skipped between two matches
+// cost a penalty.
+const (
+	scoreMatch       = 16
+	bonusFirst       = 24
+	bonusBoundary    = 12
+	bonusConsecutive = 16
+	penaltyGapStart  = 3
+	penaltyGapExtend = 1
+)
+
+// FuzzyScore reports whether every character of pattern appears in target in
+// the same order, ignoring case, and scores the best such alignment. Higher
+// scores mean better matches: "apisrv" scores higher against "api-server"
+// than against "a-p-i-s-r-v-tool", and prefixes and word starts are preferred.
+func FuzzyScore(pattern, target string) (int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(target)
+	lower := []rune(strings.ToLower(target))
+	if len(p) == 0 {
+		return 0, true
+	}
+	if len(p) > len(t) {
+		return 0, false
+	}
+
+	const none = -1 << 30
+
+	// prev[j] is the best score of matching p[:i] with p[i-1] at t[j].
+	prev := make([]int, len(t))
+	cur := make([]int, len(t))
+
+	for j := range t {
+		prev[j] = none
+		if lower[j] == p[0] {
+			prev[j] = scoreMatch + positionBonus(t, j)
+		}
+	}
+
+	for i := 1; i < len(p); i++ {
+		// gapped is the best score of p[:i] ending before t[j-1], minus the
+		// penalty for the characters skipped up to t[j].
+		gapped, hasGap := 0, false
+		for j := range t {
+			if hasGap {
+				gapped -= penaltyGapExtend
+			}
+			if j >= 2 && prev[j-2] != none {
+				if s := prev[j-2] - penaltyGapStart; !hasGap || s > gapped {
+					gapped, hasGap = s, true
+				}
+			}
+
+			cur[j] = none
+			if j == 0 || lower[j] != p[i] {
+				continue
+			}
+
+			best, ok := gapped, hasGap
+			if prev[j-1] != none {
+				if s := prev[j-1] + bonusConsecutive; !ok || s > best {
+					best, ok = s, true
+				}
+			}
+			if ok {
+				cur[j] = best + scoreMatch + positionBonus(t, j)
+			}
+		}
+		prev, cur = cur, prev
+	}
+
+	score := none
+	for _, s := range prev {
+		score = max(score, s)
+	}
+	if score == none {
+		return 0, false
+	}
+	return score, true
+}
+
+// positionBonus returns the bonus for matching the character at t[j].
+func positionBonus(t []rune, j int) int {
+	if j == 0 {
+		return bonusFirst
+	}
+
+	before, at := t[j-1], t[j]
+	switch {
+	case !unicode.IsLetter(before) && !unicode.IsDigit(before):
+		return bonusBoundary // after a separator such as - _ . / or a space
+	case unicode.IsLower(before) && unicode.IsUpper(at):
+		return bonusBoundary // camelCase
+	case !unicode.IsDigit(before) && unicode.IsDigit(at):
+		return bonusBoundary
+	}
+	return 0
+}
@@ -11,31 +11,17 @@ import (
 //
 // Examples:
 //
-//	https://github.com/user/project.git -> project
-//	git@github.com:user/project.git    -> project
-//	ssh://git@github.com/user/project  -> project
+//	https://github.com/user/project.git          -> project
+//	git@github.com:user/project.git              -> project
+//	ssh://git@host:2222/group/sub/project.git/   -> project
 //
 // If no name can be resolved, "cloned-repo" is returned.
 func ExtractRepoNameFromURL(url string) string {
-	url = strings.TrimSuffix(url, ".git")
-
-	// Handle SSH-style URLs: git@github.com:user/repo
-	if strings.Contains(url, ":") && !strings.Contains(url, "://") {
-		parts := strings.Split(url, ":")
-		if len(parts) >= 2 {
-			url = parts[1]
-		}
-	}
-
-	parts := strings.Split(url, "/")
-	if len(parts) > 0 {
-		last := strings.TrimSpace(parts[len(parts)-1])
-		if last != "" {
-			return last
-		}
+	ref, err := ParseRepoRef(url)
+	if err != nil {
+		return "cloned-repo"
 	}
-
-	return "cloned-repo"
+	return ref.Name
 }
 
 // ParseGitError provides a cleaner message for common Git failure patterns.
@@ -57,14 +43,6 @@ func ParseGitError(err error) error {
 	}
 }
 
-// BuildRepoURL builds an HTTPS or SSH GitHub repository URL.
-func BuildRepoURL(user, repo string, https bool) string {
-	if https {
-		return fmt.Sprintf("https://github.com/%s/%s.git", user, repo)
-	}
-	return fmt.Sprintf("git@github.com:%s/%s.git", user, repo)
-}
-
 // IsGitRepo checks whether the provided directory contains a .git folder.
 func IsGitRepo(path string) bool {
 	gitPath := filepath.Join(path, ".git")
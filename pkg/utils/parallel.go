@@ -0,0 +1,30 @@
+package utils
+
+import "sync"
+
+// Parallel calls fn for every index in [0, n), running at most limit calls
+// at the same time, and returns once all of them have finished.
+func Parallel(n, limit int, fn func(i int)) {
+	if limit < 1 {
+		limit = 1
+	}
+
+	queue := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < min(limit, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range queue {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		queue <- i
+	}
+	close(queue)
+	wg.Wait()
+}
@@ -91,3 +91,15 @@ func copyFile(sourcePath, destPath string) error {
 
 	return nil
 }
+
+// IsEmptyDir reports whether path is missing or an empty directory.
+func IsEmptyDir(path string) (bool, error) {
+	entries, err := os.ReadDir(path)
+	if os.IsNotExist(err) {
+		return true, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return len(entries) == 0, nil
+}
@@ -0,0 +1,118 @@
+package utils
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Protocols reported by ParseRepoRef.
+const (
+	ProtocolHTTPS = "https"
+	ProtocolHTTP  = "http"
+	ProtocolSSH   = "ssh"
+	ProtocolGit   = "git"
+	ProtocolFile  = "file"
+)
+
+// scpPattern matches scp-like SSH addresses: [user@]host:path
+var scpPattern = regexp.MustCompile(`^(?:([^@/]+)@)?([^:/]+):(.+)$`)
+
+// RepoRef is a parsed Git repository location.
+type RepoRef struct {
+	Protocol string // https, http, ssh, git, file; empty for owner/repo shorthands
+	User     string // SSH user, e.g. "git"
+	Host     string // Host name without port; empty for local paths and shorthands
+	Port     int    // Explicit port, 0 if none
+	Owner    string // Namespace path, possibly nested (e.g. "group/sub")
+	Name     string // Repository name without the .git suffix
+}
+
+// Path returns the repository path on its host, e.g. "group/sub/repo".
+func (r *RepoRef) Path() string {
+	if r.Owner == "" {
+		return r.Name
+	}
+	return r.Owner + "/" + r.Name
+}
+
+// ParseRepoRef parses a Git repository location.
+//
+// Supported forms:
+//
+//	https://host[:port]/owner/repo[.git][/]
+//	ssh://[user@]host[:port]/group/sub/repo.git
+//	git://host/owner/repo
+//	[user@]host:owner/repo.git          (scp-like SSH)
+//	file:///path/to/repo, /path/to/repo, ./repo
+//	owner/repo, repo                    (shorthands, no host)
+func ParseRepoRef(raw string) (*RepoRef, error) {
+	s := strings.TrimSpace(raw)
+	if s == "" {
+		return nil, fmt.Errorf("empty repository URL")
+	}
+
+	var ref *RepoRef
+	var repoPath string
+
+	switch {
+	case strings.Contains(s, "://"):
+		u, err := url.Parse(s)
+		if err != nil {
+			return nil, fmt.Errorf("invalid repository URL '%s': %w", raw, err)
+		}
+
+		ref = &RepoRef{Protocol: strings.ToLower(u.Scheme), Host: u.Hostname()}
+		if u.User != nil {
+			ref.User = u.User.Username()
+		}
+		if port := u.Port(); port != "" {
+			if ref.Port, err = strconv.Atoi(port); err != nil {
+				return nil, fmt.Errorf("invalid port in repository URL '%s'", raw)
+			}
+		}
+		if ref.Protocol == ProtocolFile {
+			// The whole path identifies a local repository.
+			ref.Host = ""
+		}
+		repoPath = u.Path
+
+	case isLocalPath(s):
+		ref = &RepoRef{Protocol: ProtocolFile}
+		repoPath = strings.ReplaceAll(s, `\`, "/")
+
+	case scpPattern.MatchString(s):
+		m := scpPattern.FindStringSubmatch(s)
+		ref = &RepoRef{Protocol: ProtocolSSH, User: m[1], Host: m[2]}
+		repoPath = m[3]
+
+	default:
+		ref = &RepoRef{}
+		repoPath = s
+	}
+
+	repoPath = strings.Trim(path.Clean("/"+repoPath), "/")
+	repoPath = strings.TrimSuffix(repoPath, ".git")
+	if repoPath == "" || repoPath == "." {
+		return nil, fmt.Errorf("repository URL '%s' has no repository path", raw)
+	}
+
+	ref.Name = path.Base(repoPath)
+	if dir := path.Dir(repoPath); dir != "." {
+		ref.Owner = dir
+	}
+
+	return ref, nil
+}
+
+// isLocalPath reports whether s looks like a filesystem path rather than a
+// remote address or shorthand.
+func isLocalPath(s string) bool {
+	return strings.HasPrefix(s, "/") || strings.HasPrefix(s, "./") ||
+		strings.HasPrefix(s, "../") || strings.HasPrefix(s, "~/") ||
+		// Windows drive letters would otherwise look like scp-like hosts.
+		len(s) > 2 && s[1] == ':' && (s[2] == '\\' || s[2] == '/')
+}
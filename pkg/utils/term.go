@@ -0,0 +1,35 @@
+package utils
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// IsTerminal reports whether the file is connected to a terminal.
+func IsTerminal(f *os.File) bool {
+	return term.IsTerminal(int(f.Fd()))
+}
+
+// Choose writes the options to out as a numbered list and reads the number of
+// one of them from in. It returns the index of the chosen option, or -1 if the
+// answer is empty or not a valid number.
+func Choose(in io.Reader, out io.Writer, question string, options []string) int {
+	fmt.Fprintln(out, question)
+	for i, option := range options {
+		fmt.Fprintf(out, "  %2d) %s\n", i+1, option)
+	}
+	fmt.Fprintf(out, "Number [1-%d]: ", len(options))
+
+	answer, _ := bufio.NewReader(in).ReadString('\n')
+	n, err := strconv.Atoi(strings.TrimSpace(answer))
+	if err != nil || n < 1 || n > len(options) {
+		return -1
+	}
+	return n - 1
+}
@@ -0,0 +1,20 @@
+package utils
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseVars converts key=value pairs, as given to --var flags, into a map.
+func ParseVars(pairs []string) (map[string]string, error) {
+	result := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid --var '%s': expected key=value", pair)
+		}
+		result[key] = value
+	}
+	return result, nil
+}